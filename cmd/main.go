@@ -1,8 +1,12 @@
 package main
 
 import (
+	"github.com/bwmarrin/discordgo"
 	"github.com/playwright-community/playwright-go"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 	"tmff-discord-app/internal/app/client"
 	"tmff-discord-app/internal/app/config"
@@ -30,11 +34,25 @@ func main() {
 		return
 	}
 
-	playerRepo := repository.NewPlayer(dbx, &parsedQueryTimeout)
-	seasonRepo := repository.NewSeason(dbx, &parsedQueryTimeout, conf.CurrentSeason)
-	gameRepo := repository.NewGame(dbx, &parsedQueryTimeout, conf.CurrentSeason)
-	gameService := services.NewGame(playerRepo, gameRepo, seasonRepo, conf.EloKFactor)
-	leaderboardService := services.NewLeaderboard(seasonRepo, playerRepo)
+	guildSettingsRepo := repository.NewGuildSettings(dbx, &parsedQueryTimeout)
+	guildSettingsService := services.NewGuildSettings(guildSettingsRepo)
+	err = guildSettingsService.AssignUnscopedData(conf.Discord.GuildID, conf.CurrentSeason)
+	if err != nil {
+		log.Printf("could not assign league to guild: %v", err)
+		return
+	}
+	leagues := services.NewLeagues(dbx, &parsedQueryTimeout, guildSettingsService)
+	league, err := leagues.Get(conf.Discord.GuildID)
+	if err != nil {
+		log.Printf("could not get league of guild: %v", err)
+		return
+	}
+	err = league.Seasons.SeedRules(conf.EloKFactor, conf.MaxGameAgeDays)
+	if err != nil {
+		log.Printf("could not seed season rules: %v", err)
+		return
+	}
+	managedMessagesService := services.NewManagedMessages(repository.NewManagedMessage(dbx, &parsedQueryTimeout))
 	discordClient, err := client.NewDiscord(conf)
 	if err != nil {
 		log.Fatalf("could not create discord client: %v", err)
@@ -75,7 +93,7 @@ func main() {
 		}
 	}(browser)
 
-	pages := services.NewPages(browser, conf.MaxGameAgeDays)
+	pages := services.NewPages(browser)
 	defer func(pages *services.Pages) {
 		closeErr := pages.Close()
 		if closeErr != nil {
@@ -95,25 +113,72 @@ func main() {
 		}
 	}(gameScraper)
 
-	fanFactionController := controller.NewFanFaction(conf, playerRepo, gameService, leaderboardService, gameScraper)
-	commands, commandHandlers := fanFactionController.FanFactionCommands()
+	profileScraper, err := pages.NewProfileScraper()
+	if err != nil {
+		log.Printf("could not create profile scraper: %v", err)
+		return
+	}
+	defer func(profileScraper *services.ProfileScraper) {
+		closeErr := profileScraper.Close()
+		if closeErr != nil {
+			log.Printf("could not close profile scraper: %v", closeErr)
+		}
+	}(profileScraper)
 
-	err = discordClient.Initialize(commands, commandHandlers)
+	// Games that were being registered when the bot stopped are registered again
+	registrationQueue := services.NewRegistrationQueue(repository.NewRegistrationJob(dbx, &parsedQueryTimeout))
+	err = registrationQueue.ResumeInterrupted()
 	if err != nil {
-		log.Printf("could not initialize discord client: %v", err)
+		log.Printf("could not resume registration jobs: %v", err)
 		return
 	}
 
-	err = fanFactionController.UpdateLeaderboard(discordClient.Client, conf.Discord.GuildID, "leaderboard")
+	fanFactionController := controller.NewFanFaction(
+		leagues,
+		guildSettingsService,
+		managedMessagesService,
+		gameScraper,
+		profileScraper,
+		services.NewRateLimiter(repository.NewCommandUse(dbx, &parsedQueryTimeout)),
+		registrationQueue,
+	)
+	router := fanFactionController.Router()
+
+	err = discordClient.Initialize(
+		router.ApplicationCommands(),
+		router.Handle,
+		fanFactionController.FanFactionComponents(),
+		func(s *discordgo.Session, guildID string) {
+			updateErr := fanFactionController.UpdateLeaderboard(s, guildID)
+			if updateErr != nil {
+				log.Printf("could not update leaderboard of guild %s: %v", guildID, updateErr)
+			}
+		},
+	)
 	if err != nil {
-		log.Printf("could not update leaderboard on start: %v", err)
+		log.Printf("could not initialize discord client: %v", err)
 		return
 	}
 
 	discordClient.SetBotStatus()
 
+	// The worker finishes the game it is registering before the browser is closed
+	stopWorker := make(chan struct{})
+	workerDone := make(chan struct{})
+	go func() {
+		fanFactionController.RunRegistrationWorker(discordClient.Client, stopWorker)
+		close(workerDone)
+	}()
+	defer func() {
+		close(stopWorker)
+		<-workerDone
+	}()
+
 	log.Println("Bot is running. Press CTRL+C to exit.")
 
-	// Await a signal to exit
-	select {}
+	// Await a signal to exit, the deferred calls close the Discord connection and the browser
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	<-stop
+	log.Println("Shutting down.")
 }
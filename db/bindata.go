@@ -2,6 +2,21 @@
 // sources:
 // db/migrations/1_create-tables.up.sql
 // db/migrations/2_create-s1.up.sql
+// db/migrations/3_season-end.up.sql
+// db/migrations/4_season-min-games.up.sql
+// db/migrations/5_season-rules.up.sql
+// db/migrations/6_season-sign-up.up.sql
+// db/migrations/7_player-discord-link.up.sql
+// db/migrations/8_registration-requests.up.sql
+// db/migrations/9_player-active.up.sql
+// db/migrations/10_player-accounts.up.sql
+// db/migrations/11_player-name-key.up.sql
+// db/migrations/12_guild-settings.up.sql
+// db/migrations/13_multi-guild.up.sql
+// db/migrations/14_managed-messages.up.sql
+// db/migrations/15_command-uses.up.sql
+// db/migrations/16_registration-jobs.up.sql
+// db/migrations/17_void-games.up.sql
 // DO NOT EDIT!
 
 package db
@@ -69,7 +84,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var __1_createTablesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x54\xd1\x8e\xa2\x40\x10\x7c\xe7\x2b\xfa\x11\x93\xf5\x0b\xee\x89\xf3\xda\x0d\x39\x1d\xf7\xc6\x21\xd9\x7d\x22\x2d\xf4\xea\x24\xec\x60\x60\xee\x92\xfb\xfb\x0b\x0c\xea\xb8\x08\x9e\xae\x8f\x5a\x3d\x95\xea\xae\x2a\xa6\x53\xd8\x17\xf4\x97\xab\x1a\x2c\x6d\x0a\x86\xac\x34\x96\xb4\xa9\x81\x8a\xe2\x04\xed\xc8\xc2\x9e\x2a\xab\x33\xbd\x27\xcb\xa0\x0d\xd8\x1d\x43\xc1\xb4\xfd\xcd\xc1\x4c\x62\xa4\x10\x54\xf4\x7d\x81\x10\xcf\x41\xac\x14\xe0\x6b\xbc\x56\xeb\x23\x43\x18\x00\x00\xe8\x1c\x62\xa1\xf0\x19\x25\xbc\xc8\x78\x19\xc9\x37\xf8\x89\x6f\x10\x25\x6a\x15\x8b\x99\xc4\x25\x0a\xf5\xd4\x4e\x6e\xb6\x94\xea\x1c\x14\xbe\xaa\x96\x4e\x24\x8b\x05\x24\x22\xfe\x95\xa0\x1b\x30\xf4\xc1\x23\x70\x56\x31\x59\xce\x53\xb2\xa0\xe2\x25\xae\x55\xb4\x7c\x81\x1f\x38\x8f\x92\x85\x82\x59\x22\x25\x0a\x95\x9e\x90\x03\x47\x30\xf9\x16\x04\xd3\x29\xd4\x4c\x75\x69\x2e\xde\xe4\x08\x35\x37\xd9\xd1\x1f\x86\x0d\xb3\x71\x8b\xe6\x63\xa7\x38\x3c\x0c\x3f\xe9\xf7\x2e\xf1\x40\xed\xe9\xd1\x2e\x63\xaf\x7b\xdb\xee\xe1\x19\x9c\x37\x0e\x53\x47\x05\x64\xf2\xc6\x6e\x5d\x1d\xfe\xe0\xa2\xbc\xbe\xe9\xb9\x82\x5b\x03\xd0\x71\xf4\x6d\x76\xb0\x53\x9f\x7a\x7c\xe7\x38\x17\xe5\x00\xb2\xa5\x0f\xae\x53\x67\xd7\xc0\xc8\x9d\x06\xb8\xc7\x2e\x85\xa1\xa7\xff\xe9\xa4\x76\xe2\x66\xe6\x2b\x89\xf1\xb3\x68\x76\xf7\x07\x27\x20\x71\x8e\x12\xc5\x0c\x8f\x71\x09\x5b\xa0\xff\xec\x44\xe9\x3f\xea\x4c\x0d\x75\x3e\x39\xc4\xa1\xdd\xf7\x52\x00\x3a\xe0\x52\x8c\x3b\xf7\xf7\x9c\xe9\x77\x9d\x75\x52\xc6\x1c\x77\x5c\x61\xaf\xba\xbd\x6c\x5f\xb1\xf5\x4b\x97\xbf\xf9\xaa\xfe\x89\x1e\xd2\x97\x86\xc8\x6f\x4b\x56\x56\xee\x77\x13\xc7\x6c\x47\x66\x3b\xfa\xb1\xec\xeb\xb8\xb5\x35\x2d\xc3\xe7\xef\xe6\xff\x35\xc6\x89\x1d\x6c\x53\xea\xe4\x8f\x0c\x6c\xf8\x7d\x98\xe1\x11\x95\xea\x96\x1b\xaf\x53\x37\x74\x66\x7a\x9b\xce\xd0\xe5\xf2\xde\x2a\xfd\x0b\x00\x00\xff\xff\xfa\x0b\xed\x86\x29\x07\x00\x00")
+var __1_createTablesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xad\x54\x4d\x6f\x82\x40\x10\xbd\xf3\x2b\xe6\x88\x89\xfe\x02\x4f\x48\x07\xb3\xa9\x80\xc5\x25\xd1\x13\x59\x61\x55\x12\xba\x10\xa0\x4d\xfa\xef\xbb\xb0\xa0\x58\x05\xeb\x07\x37\xf6\xcd\x3e\xde\xcc\x7b\xc3\x0c\xe7\xc4\x99\x6a\x93\x09\x64\x09\xfb\xe1\x79\x01\x25\xdb\x26\x1c\xc2\x54\x94\x2c\x16\x05\xb0\x24\x39\x41\x07\x56\x42\xc6\xf2\x32\x0e\xe3\x8c\x95\x1c\x62\x21\xcf\x38\x24\x9c\xed\xbf\xb8\x66\x7a\x68\x50\x04\x6a\xcc\x16\x08\xc4\x02\xc7\xa5\x80\x6b\xb2\xa2\xab\x23\x83\xae\x81\x7c\xe2\x08\x88\x43\x71\x8e\x1e\x2c\x3d\x62\x1b\xde\x06\xde\x71\x03\x86\x4f\x5d\xe2\x48\x16\x1b\x1d\x3a\xae\x2b\xb7\x7b\x16\xc8\x6a\x8a\x6b\x5a\xd3\x39\xfe\x62\x01\xbe\x43\x3e\x7c\x54\x05\x82\x7d\xf2\x01\x38\xcc\xb9\xd4\x19\x05\x52\x37\x25\x36\xae\xa8\x61\x2f\xe1\x0d\x2d\xc3\x5f\x50\x30\x7d\xcf\x93\x5f\x0a\x4e\x48\xcb\xa1\x8d\xa6\x5a\x35\x93\x82\xb3\x22\x15\x57\x67\x72\x84\xaa\x99\x1c\xd8\x37\x87\x2d\xe7\x42\x35\x1a\x0d\x8d\xa2\xbd\xa8\xff\xd1\xdf\x99\xc4\x0b\xb5\x07\x47\xbb\x44\x79\xdb\xdb\xba\x8f\x8e\xc1\x51\xe5\x30\x6b\xa8\x80\x89\xa8\xb2\x3b\xce\xdb\x03\x9e\xa4\xb7\x3b\x3d\x57\x70\x6f\x00\x1a\x8e\x4b\x9b\x15\xac\xd4\x07\x1d\xbe\x73\x5c\x2a\xec\x41\xf6\x92\xb1\x08\x94\x5d\x3d\x25\x0f\x1a\xa0\x2e\xab\x14\xea\x1d\xfd\xe3\x93\xda\x91\xaa\xb1\x5c\x0f\xc9\xdc\xa9\x7a\xef\x16\x8e\xc0\x43\x0b\x25\xbb\x89\xc7\xb8\xe8\x35\x70\x79\xed\x44\xd9\xbd\xd4\x98\xaa\xcb\xe3\x36\x0e\x75\xbf\xd7\x02\xd0\x00\xd7\x62\xdc\xb8\x9f\xf1\x30\xde\xc5\x61\x23\x65\xc8\x71\xc5\xa5\x5f\xac\xee\x45\xb6\x6f\xd8\xfa\xd4\xe4\xef\x9e\x6a\x77\x44\x2f\xd9\x97\x8a\xa8\xbb\x2d\x61\x9a\xab\xf7\x2a\x8e\xe1\x81\x89\x3d\xbf\x35\xc5\xe7\xb6\xa6\x66\xf8\xfb\xdf\xfc\xdf\xc6\x28\xb1\xbd\xdb\x14\x28\xf9\x03\x05\x5b\xbe\xeb\x67\x78\xc5\x4a\x35\xcd\x0d\xaf\x53\x53\x74\x66\x7a\x9d\x4e\x5d\xe5\xf2\xd1\x55\x32\x5d\xdb\x26\x74\xaa\xfd\x02\xcd\x99\x16\xc1\x38\x07\x00\x00")
 
 func _1_createTablesUpSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -84,12 +99,12 @@ func _1_createTablesUpSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "1_create-tables.up.sql", size: 1833, mode: os.FileMode(493), modTime: time.Unix(1728924391, 0)}
+	info := bindataFileInfo{name: "1_create-tables.up.sql", size: 1848, mode: os.FileMode(493), modTime: time.Unix(1728924391, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var __2_createS1UpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xf2\xf4\x0b\x76\x0d\x0a\x51\xf0\xf4\x0b\xf1\x57\x28\x4e\x4d\x2c\xce\xcf\x2b\x56\xd0\xc8\x4b\xcc\x4d\xd5\x54\x08\x73\xf4\x09\x75\x0d\x56\xd0\x50\x77\xcb\x2c\x2a\x2e\x51\x70\x4b\xcc\x53\x70\x4b\x4c\x2e\xc9\xcc\xcf\x53\x08\x06\xab\x54\xd7\xb4\x06\x04\x00\x00\xff\xff\x65\x7f\x00\x4f\x3f\x00\x00\x00")
+var __2_createS1UpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xf3\xf4\x0b\x76\x0d\x0a\x51\xf0\xf4\x0b\xf1\x57\x28\x4e\x4d\x2c\xce\xcf\x2b\x56\xd0\xc8\x4b\xcc\x4d\xd5\x54\x08\x73\xf4\x09\x75\x0d\x56\xd0\x50\x77\xcb\x2c\x2a\x2e\x51\x70\x4b\xcc\x03\xe2\xe4\x92\xcc\xfc\x3c\x85\x60\xb0\x4a\x75\x4d\x6b\x00\x65\x7f\x00\x4f\x3f\x00\x00\x00")
 
 func _2_createS1UpSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -109,6 +124,306 @@ func _2_createS1UpSql() (*asset, error) {
 	return a, nil
 }
 
+var __3_seasonEndUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x6d\x8e\x41\x6a\xc3\x30\x10\x45\xf7\x3a\xc5\x3f\x40\x73\x02\xaf\x9c\x44\x04\x83\xe5\x14\x57\x59\x9b\xa9\x35\xb6\x45\x92\x91\x91\x44\x72\xfd\x28\x24\xd0\x16\xba\x1b\xfe\x7c\xde\xfb\x5b\x7d\x68\xba\x4a\x6d\x36\x60\x71\xec\x06\xca\xf0\x09\x89\x33\xee\x0b\x0b\xa8\x9c\x94\x82\x3c\xc3\xf1\x12\x12\xbb\x0f\xd0\x94\x39\x96\xb7\x1f\x17\x48\xc0\x35\x44\xc6\x4c\x57\x2e\x0d\x12\x7c\x33\x22\xcf\x3e\x95\x0e\x3b\x55\xb7\x56\xf7\xb0\xf5\xb6\xd5\x6f\x52\x42\xbd\xdf\x63\x77\x6c\x4f\xa6\xfb\x71\xda\xc6\xe8\x2f\x5b\x9b\xcf\x4a\x3d\xb7\x4c\x5e\xe8\x32\x44\x92\x33\x28\x8e\x8b\xbf\x15\x78\x5e\xf8\x95\x23\x65\x12\xe7\x65\x4e\x08\x53\x59\xf8\xda\xf5\xc6\xff\x63\x1c\x56\x8a\xd9\x8f\x7e\x25\xc9\x7f\xec\xbf\x2c\x4d\x67\xf5\x41\xf7\x95\xda\x1d\x8d\x69\x6c\xa5\x1e\x0d\x06\x81\xbb\x18\x01\x00\x00")
+
+func _3_seasonEndUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__3_seasonEndUpSql,
+		"3_season-end.up.sql",
+	)
+}
+
+func _3_seasonEndUpSql() (*asset, error) {
+	bytes, err := _3_seasonEndUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "3_season-end.up.sql", size: 280, mode: os.FileMode(493), modTime: time.Unix(1792383165, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __4_seasonMinGamesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x4d\x8e\xcd\x0e\x82\x30\x10\x84\xef\x3c\xc5\xbc\x00\x89\x77\x4f\x55\xaa\x31\xa9\x25\x31\xed\xd9\x2c\xa1\x40\x23\x2c\x84\xc5\x83\x6f\x6f\xab\x1e\x3c\xce\x37\x3f\x99\xb2\xc4\x14\xf9\xde\xd3\x14\x04\x51\xb0\x0d\x01\xfc\x9c\x9a\xb0\x62\xee\xf0\xc5\x84\x65\xa4\x57\x22\x03\xa5\xc0\xfc\x51\x88\x9c\xb8\x04\x92\x99\x33\x6b\x02\x56\xe2\x47\x68\xb3\x91\x47\xba\xc8\x34\x42\x36\xe2\x36\x72\x2f\x85\x32\x4e\xdf\xe0\xd4\xc1\xe8\x5f\x4d\xa0\xaa\x0a\xc7\xda\xf8\xab\xfd\x3b\x71\xb1\x4e\x9f\x53\xd4\xd6\x0e\xd6\x1b\x83\x4a\x9f\x94\x37\x0e\xbb\x7d\xf1\x06\x45\xb3\xe2\x72\xae\x00\x00\x00")
+
+func _4_seasonMinGamesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__4_seasonMinGamesUpSql,
+		"4_season-min-games.up.sql",
+	)
+}
+
+func _4_seasonMinGamesUpSql() (*asset, error) {
+	bytes, err := _4_seasonMinGamesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "4_season-min-games.up.sql", size: 174, mode: os.FileMode(493), modTime: time.Unix(1792383280, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __5_seasonRulesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x75\x52\x41\x6e\xc2\x30\x10\xbc\xe7\x15\x7b\xa4\x52\x79\x41\x4f\x29\x38\x28\x02\x52\x14\x8c\xd4\x9e\xac\x85\x6c\x82\x55\xc7\x46\xb1\x29\xe5\xf7\xdd\x38\xa1\x2d\x07\x72\x4b\x66\x66\xb3\x3b\x33\xd3\x29\x78\x42\xef\xac\xea\xce\x86\x3c\x04\xdc\x1b\x82\x83\xb3\x01\xb5\xe5\xd7\x23\xc1\x00\xe0\xc8\x03\xed\xa1\xc3\x40\x15\xec\xaf\xcf\x3d\x7e\x05\xec\x88\xc1\x00\x97\x23\xd9\xa8\xf8\x63\x1e\x3a\xea\xb9\xc9\xac\x14\xa9\x14\x20\xd3\xd7\x95\x80\x3c\x83\xe2\x4d\x82\x78\xcf\xb7\x72\x7b\xff\xfb\x49\x02\xfc\x8c\x9f\x2c\xb6\x04\x52\xbc\x4b\xd8\x94\xf9\x3a\x2d\x3f\x60\x29\x3e\x9e\x23\xe3\x53\xd5\x78\x08\xae\x83\xbc\x90\x62\x21\xca\x38\xb0\xd8\xad\x56\x03\xec\x03\x76\x41\x91\x71\x0f\x70\x46\x54\x6d\xdc\x43\x7d\xab\xad\x3a\x19\xbc\x52\xe7\x1f\x31\xf0\x5b\x35\xbc\x9f\xc2\x86\x54\x85\xd7\x47\xbc\xe9\x94\xcd\x6c\xdb\xde\xbd\x13\x0e\xbe\x19\xed\x03\xb8\x3a\x3a\x55\xa3\x85\xfe\x12\xcd\x76\xb1\x85\x41\xdb\xa6\xb7\xba\x9f\x0c\x07\xc6\xf6\x04\x71\x8f\x0a\x2e\x3a\x1c\xe3\x44\x34\xc6\x5d\xa8\x62\x03\xac\x1a\xa5\xea\x57\x1a\xdd\xba\xdf\x60\x8c\x40\x61\x00\x99\xaf\xc5\x56\xa6\xeb\x0d\xcc\x45\x96\xee\x56\x12\x66\xbb\xb2\x14\x85\x54\x7f\xc8\xbd\x38\x7b\x2b\x45\xbe\x28\x7a\xe3\x27\xff\x52\x79\x82\x52\x64\x82\x95\x33\x71\x0b\xd0\x4f\x22\x90\x3c\xbd\x24\x09\x1f\x2d\x7f\x8b\x33\x5e\x3a\xb2\x6e\xeb\xf0\x65\xb5\xe3\xde\x84\xa3\xbe\x95\x6e\xa8\x11\x55\x0c\xf2\xae\x31\xc2\xf3\x09\xea\xce\xb5\x71\x00\x67\xb6\xcc\x86\xd0\xd1\x56\x7d\x00\x0b\xfe\x61\xda\xd0\x9c\xdd\x4f\xa2\xcf\xb6\xd6\x0d\x7c\xa1\x39\x93\x1f\x9a\x79\x21\x9e\x79\x6b\x6b\xf2\x03\xc0\x9d\xb0\x2c\xec\x02\x00\x00")
+
+func _5_seasonRulesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__5_seasonRulesUpSql,
+		"5_season-rules.up.sql",
+	)
+}
+
+func _5_seasonRulesUpSql() (*asset, error) {
+	bytes, err := _5_seasonRulesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "5_season-rules.up.sql", size: 748, mode: os.FileMode(493), modTime: time.Unix(1792383387, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __6_seasonSignUpUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x65\x8d\x31\x0e\xc2\x30\x10\x04\xfb\xbc\x62\x3f\x90\x17\x50\x39\xc4\xa9\x0e\x5b\x02\xa7\x8e\x4e\x70\x4a\x8c\xc0\x0e\x3e\x07\x89\xdf\x93\x82\x8e\x6e\x67\x34\xd2\xb6\x2d\x34\xce\x69\xda\xd6\xa9\xc8\x6b\x8b\x45\x6e\x28\xa2\xb5\xc4\x6b\x55\x14\xae\x3b\xcf\xfc\x14\x45\xcd\x58\x1f\xfc\x91\xb2\xcf\x85\x2b\x16\x7e\x0b\xee\x39\xa6\xbd\xa8\x8b\x40\x85\x35\xa7\xc6\x50\xb0\x67\x04\xd3\x91\xfd\x29\x85\xe9\x7b\x1c\x3d\x8d\x27\xf7\x7f\xd6\x79\x4f\xd6\x38\x38\x1f\xe0\x46\x22\xf4\x76\x30\x23\x05\x0c\x86\x2e\xf6\xd0\x7c\x01\xa8\x5a\x3a\x98\xa1\x00\x00\x00")
+
+func _6_seasonSignUpUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__6_seasonSignUpUpSql,
+		"6_season-sign-up.up.sql",
+	)
+}
+
+func _6_seasonSignUpUpSql() (*asset, error) {
+	bytes, err := _6_seasonSignUpUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "6_season-sign-up.up.sql", size: 161, mode: os.FileMode(493), modTime: time.Unix(1792383526, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __7_playerDiscordLinkUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x6d\x51\xcb\x6e\x83\x30\x10\xbc\xf3\x15\x73\x24\x52\xf3\x05\x39\x11\x58\x22\xab\x60\x52\xc7\x48\xc9\x09\xb9\xe0\xb6\xa8\x09\x4e\x8d\xd3\xa8\x7f\x5f\x13\xf2\x6a\x54\x1f\x67\x76\x76\x67\xc6\x73\x5a\x30\x3e\x0b\xa6\x53\x34\x6d\x5f\x1b\xdb\x54\x6d\x83\x6d\xdb\x7d\xf6\x50\xd8\x6f\xd5\x8f\xb6\x70\x06\xee\x43\x23\x19\x07\xa0\xea\xda\x1c\x3a\xe7\x31\xe5\x60\x8e\x5d\x8f\xd6\x05\x51\x26\x49\x40\x46\xf3\x8c\xce\xb2\x1e\x51\x92\x20\x2e\xb2\x32\xe7\xf7\xcb\x25\xad\xe5\x2c\x88\x05\x45\x92\x50\x72\xf6\x52\x12\x18\x4f\x68\x0d\x96\x82\x17\x12\xb4\x66\x2b\xb9\xba\x6c\xa9\xee\xa4\x05\xbf\xa0\xe1\x0d\x9d\xcc\x82\xc1\xfe\x48\x54\x83\xf5\xca\xea\xaf\x83\xee\x5d\x0f\xa7\x5e\xb7\x1a\xb5\xe9\x9c\x6a\xbd\xcf\x21\xc5\x98\xed\xe4\x5d\x59\x8d\xa3\x6a\x5d\xdb\xbd\xe3\xcd\x58\x1f\x78\x67\x1a\x6d\x95\x33\xa7\xcc\x6a\xbf\xb7\xe6\x5b\x0f\xaa\xdd\xc5\xef\x18\xf0\x3f\xa3\x0f\x97\xc3\x00\xfe\x3d\xc4\xc6\x52\xb0\x3c\x12\x1b\x3c\xd3\xe6\xe9\x34\x70\xd6\x7a\x9e\x71\x49\x0b\x5f\xe1\xb0\x98\x97\x59\x36\xf2\xb5\xd5\xca\xe9\xa6\xf2\x6e\x25\xcb\x69\x25\xa3\x7c\x89\x84\xd2\xa8\xcc\x24\xe2\x52\x08\xe2\xb2\xba\x31\x7f\xc5\x69\x21\x88\x2d\xf8\x70\x2d\xbc\x1e\x9a\x40\x50\x4a\x5e\x17\xd3\xb5\xe3\xd0\xc3\x81\xef\x31\x2e\xf2\x9c\xf9\xcf\xf9\x05\xf4\xdf\x0b\xe5\x15\x02\x00\x00")
+
+func _7_playerDiscordLinkUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__7_playerDiscordLinkUpSql,
+		"7_player-discord-link.up.sql",
+	)
+}
+
+func _7_playerDiscordLinkUpSql() (*asset, error) {
+	bytes, err := _7_playerDiscordLinkUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "7_player-discord-link.up.sql", size: 533, mode: os.FileMode(493), modTime: time.Unix(1792384007, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __8_registrationRequestsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8d\x50\xcb\x6e\xc2\x30\x10\xbc\xe7\x2b\xf6\x06\x48\xf0\x05\xa8\x87\x00\x0b\xb5\x9a\x38\xd4\x38\x2a\x9c\x22\x13\xaf\xa8\x2b\xf2\xa8\x6d\xa8\xf8\xfb\x9a\x00\x45\x95\x8a\x54\x9f\xd6\x33\xbb\xb3\xb3\x33\xc1\x05\xe3\xe3\x68\x34\x02\x4b\x3b\xe3\xbc\x55\xde\x34\x75\x61\xe9\xf3\x40\xce\x3b\xf0\x6a\xbb\x27\x28\x9b\xda\x2b\x53\x87\xef\x3b\x41\xbb\x57\x27\xb2\xe7\x5a\xf9\xeb\x14\x59\xd2\x67\xae\x72\xb4\x3f\x92\x1b\x82\x82\xaa\xd1\x14\xc4\x1a\x0b\xaa\x6d\x6d\x13\x50\x08\xb5\xa5\x0f\x2a\x7d\xa7\x53\x45\x53\x81\xb1\x44\x90\xf1\x24\x41\x60\x73\xe0\x99\x04\x5c\xb3\x95\x5c\x3d\x30\xd3\x8f\x20\x3c\xa3\x81\x71\x89\x0b\x14\xb0\x14\x2c\x8d\xc5\x06\x5e\x70\x03\x71\x2e\x33\xc6\x83\x66\x8a\x5c\x0e\xbb\x4e\x6d\x5c\xd9\x58\x5d\x84\x09\x89\x6b\xd9\x2d\xe0\x79\x92\x5c\xd8\x5a\x55\xf4\x17\xbe\xdd\xa9\x07\x13\xce\x2b\x7f\x70\xbf\x19\x98\xe1\x3c\xce\x13\x09\xbd\x96\x6a\x6d\xea\x5d\xef\xd2\x6b\xe9\x68\xe8\x8b\x74\xb1\x3d\x75\x03\x17\xb4\xb4\xa4\x7c\x00\x43\x72\x92\xa5\xb8\x92\x71\xba\xfc\x51\x98\xe6\x42\x04\xef\xc5\x9d\xb9\x2d\x89\x06\xe3\x5b\x5a\x39\x67\xaf\x79\x88\x8b\xcf\x70\xfd\x9f\xd0\x8a\xab\x2d\xc8\xf8\xdf\x0d\xfd\x7b\x4a\x83\xce\xe3\xdb\x33\x0a\xbc\xdd\xfa\x74\xbf\x2b\x58\xc8\xd2\x94\xc9\x71\xf4\x0d\xd0\x47\xd8\x76\x35\x02\x00\x00")
+
+func _8_registrationRequestsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__8_registrationRequestsUpSql,
+		"8_registration-requests.up.sql",
+	)
+}
+
+func _8_registrationRequestsUpSql() (*asset, error) {
+	bytes, err := _8_registrationRequestsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "8_registration-requests.up.sql", size: 565, mode: os.FileMode(493), modTime: time.Unix(1792384099, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __9_playerActiveUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x35\xcd\x41\x0e\xc2\x20\x10\x46\xe1\x7d\x4f\xf1\x1f\xc0\x9e\xc0\x15\x15\x5c\x8d\x90\x34\x70\x80\x89\x9d\x56\x12\x2c\x66\x20\x4d\xbc\xbd\x6e\xba\x7f\x5f\xde\x38\x82\x9f\x3d\x1f\x82\xdc\xb0\x72\x69\x82\xb5\x2a\x54\x7a\x56\x59\xf0\x29\xfc\x15\x6d\x17\xf4\x97\x64\xc5\xc6\x6f\x69\x60\x15\xec\x15\xa5\xee\x9b\xfc\x53\xee\xb2\x0c\x86\xa2\x9b\x11\xcd\x44\xee\x44\x30\xd6\xe2\x16\x28\x3d\xfc\xf9\x98\x42\x20\x67\x3c\x7c\x88\xf0\x89\x08\xd6\xdd\x4d\xa2\x88\x38\x27\x77\x1d\x7e\x62\x00\x94\x22\x8d\x00\x00\x00")
+
+func _9_playerActiveUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__9_playerActiveUpSql,
+		"9_player-active.up.sql",
+	)
+}
+
+func _9_playerActiveUpSql() (*asset, error) {
+	bytes, err := _9_playerActiveUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "9_player-active.up.sql", size: 141, mode: os.FileMode(493), modTime: time.Unix(1792384205, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __10_playerAccountsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x75\x8f\xcd\x6e\x83\x30\x10\x84\xef\x3c\xc5\x1c\x13\xa9\x79\x82\x9e\x1c\xba\x20\xab\x60\x52\x63\x4b\xc9\x09\x39\xe0\xb4\x48\x60\x57\xc4\x3d\xf4\xed\x6b\xf2\x43\x5b\x55\xf5\x6d\x67\xe6\x5b\xcf\x6e\x36\x78\x1f\xcc\xa7\x9d\x1a\xd3\xb6\xfe\xc3\x85\x33\x82\x39\x0e\x16\xad\x77\xc1\xf4\x2e\x8e\x6f\x16\xa6\xeb\xfa\xd0\x7b\x67\x06\x6c\x73\x86\x25\xea\x4f\x37\x7a\x8e\x99\x70\x19\x70\x9a\xfc\x88\xd1\x4f\x76\xd6\x1c\xbc\xb3\x77\x20\x49\x25\x31\x45\x50\x6c\x5b\x10\x78\x06\x51\x29\xd0\x9e\xd7\xaa\xfe\xd3\x62\x95\x20\xbe\xbe\x03\x17\x8a\x72\x92\xd8\x49\x5e\x32\x79\xc0\x33\x1d\xc0\xb4\xaa\xb8\x88\xdb\x4a\x12\xea\xe1\x92\xbc\xf1\x3f\x80\x79\xb9\xd0\x45\x71\xf5\x9d\x19\x2d\x14\xed\xd5\xa2\x43\x0b\xfe\xa2\xe9\x6a\x1f\x5f\xcd\xcc\xfe\x1f\x68\x27\x6b\x82\xed\x9a\x78\xa5\xe2\x25\xd5\x8a\x95\x3b\x3c\x51\xc6\x74\xa1\x90\x6a\x29\x63\x93\xe6\xdb\xf9\xfd\x79\x56\x49\xe2\xb9\x98\xbb\xaf\x96\xa2\x6b\x48\xca\x28\x72\x29\xdd\xcf\x3f\xaf\xa2\x9c\xac\x1f\x93\x2f\x2b\x95\x7f\x2d\x97\x01\x00\x00")
+
+func _10_playerAccountsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__10_playerAccountsUpSql,
+		"10_player-accounts.up.sql",
+	)
+}
+
+func _10_playerAccountsUpSql() (*asset, error) {
+	bytes, err := _10_playerAccountsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "10_player-accounts.up.sql", size: 407, mode: os.FileMode(493), modTime: time.Unix(1792384618, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __11_playerNameKeyUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xcd\x51\xcd\x8e\xda\x30\x10\xbe\xe7\x29\xe6\x46\x22\xb1\xfb\x00\x5d\xf5\x00\x89\xd9\x46\x22\xce\x36\x38\x2a\x3d\x21\x6f\x3c\x80\x55\xe2\x50\xdb\x68\x4b\xb5\x0f\x5f\x4f\x28\xc9\xaa\x42\x6a\xab\x5e\x7a\xb1\xe5\x99\xf9\x7e\xe6\xf3\x9c\x3d\xe6\xfc\x21\xba\xbb\x03\x23\x5b\xdc\x7c\xc1\x33\x68\x07\x7e\x8f\xfd\x1b\x4c\x67\x5b\x79\xd0\xdf\x51\xc1\xb6\xb3\xd0\x4a\xdf\xec\xb5\xd9\x41\x5c\x1b\xdd\x74\x0a\x81\x2f\xd2\x29\x34\xd2\x61\xe8\x1f\x14\xaa\x64\xda\x03\x1d\x48\x8b\x70\x32\xfa\xeb\x09\xe1\xf9\x4c\x84\xda\x42\x60\xbf\x27\x29\xde\x4f\xf8\xbd\xf4\xd0\x99\xc3\x19\x94\xde\x6e\xd1\x82\x36\x17\xa6\x20\x84\x26\xb0\x93\xd0\x0e\x7d\xef\xc6\x91\x9b\x80\x7f\xd7\xbf\x48\xca\x79\xb0\xdd\x4b\xa8\xe1\xd1\x81\xf6\xee\x62\x58\x1a\x75\x99\x08\x87\x75\x57\xb8\xb6\x24\x9b\x67\x20\x8f\x47\x34\xc1\xe6\x14\x5c\xd7\xcf\xfd\xb4\xa8\x43\xf1\x5b\xf0\xd4\x48\x03\xcf\x08\x8d\x45\xe9\xc3\xce\xc4\x26\xa1\x0d\x8b\x5a\xe9\x83\x2d\x6a\x5b\xec\x85\x28\x0d\xb4\x3b\x24\x96\x16\xe4\xd6\xa3\x7d\x91\x56\xb9\xfb\x68\xb6\x14\xac\x02\x31\x9b\x2f\x19\x1c\x0f\xf2\x4c\x3e\x66\x59\x06\x69\xb9\xac\x0b\x3e\xe6\x2c\xd8\x5a\x3c\x44\xf5\x53\x36\x13\xe3\xe0\x8a\x89\x71\xe2\xfd\x98\xff\x86\x8a\x31\x1d\xc9\xaf\x98\xe8\x8a\xa1\x79\xba\x5e\x5f\x61\x02\xf1\x84\x6e\xad\xfa\x57\x32\x99\xfe\x86\xf5\x26\x28\x89\x3e\x7d\x60\x15\xa3\x02\x2f\x05\xe4\x1c\xe2\x15\x5b\xb2\x54\x40\x91\xf3\x58\xab\x04\x16\x55\x59\x0c\xde\x1f\xab\xb2\x7e\x82\xf9\xe7\x41\x2a\x58\x4d\x2b\x46\x56\x6b\x9e\x7f\xac\x59\x60\xc8\xd8\x1a\xf2\x45\x4f\xc7\xd6\xf9\x4a\xac\xae\xe8\xcd\xe0\xaf\xe4\xd7\x5a\xfc\x86\xe8\x46\xac\x1b\xd9\x34\xdd\xc9\xf8\x3f\x8e\x77\x04\xfc\x7d\xcc\x03\xf6\x3f\x89\x7b\xdc\xe5\x5f\x62\x1f\x58\x6e\xc4\x3f\xf4\xde\x7e\x43\x5a\x16\x45\x1e\x72\xfd\x01\xc0\xed\x0a\xdb\x38\x04\x00\x00")
+
+func _11_playerNameKeyUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__11_playerNameKeyUpSql,
+		"11_player-name-key.up.sql",
+	)
+}
+
+func _11_playerNameKeyUpSql() (*asset, error) {
+	bytes, err := _11_playerNameKeyUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "11_player-name-key.up.sql", size: 1080, mode: os.FileMode(493), modTime: time.Unix(1792384874, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __12_guildSettingsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x95\x51\x3d\x6f\xc2\x30\x10\xdd\xf3\x2b\x6e\xa3\x95\x40\xea\xd4\xa5\x53\x00\x53\x45\x0d\x01\x05\x23\xc1\x64\x1d\xf1\x01\x56\x1d\x3b\xb2\x9d\x4a\xfc\xfb\x3a\xa4\x4d\xab\x32\xb4\xf5\x64\xdf\xbd\x0f\xbf\xbb\xc9\x04\x4e\xad\xd2\x52\x78\x0a\x41\x99\x93\x87\x80\x07\x4d\x50\x59\x13\x50\x99\xf8\x3c\x13\x0c\x3d\x7b\x04\xc2\xea\x0c\x73\xe5\x2b\xeb\x64\x6c\xb8\x37\x72\x63\xa8\xce\x68\x0c\x69\x0f\x68\x24\x38\xab\xa9\xe3\x61\x00\x74\x04\xc6\x86\x4e\xe0\x7a\xd7\xd6\xbe\x92\x84\xb6\x49\x26\x13\x38\x5c\x3a\x71\xe5\x40\xd2\x11\x5b\x1d\xc0\x60\x4d\xc9\xac\x64\x29\x67\xc0\xd3\x69\xce\x20\x5b\x40\xb1\xe2\xc0\x76\xd9\x86\x6f\x7e\x7e\xf4\x2e\x81\x78\xfa\xa2\x92\xc0\xd9\x8e\xc3\xba\xcc\x96\x69\xb9\x87\x17\xb6\x1f\xf7\xed\xa8\xe9\xc5\xc7\xff\x06\x58\x27\x5a\x6c\xf3\x1c\xe6\x6c\x91\x6e\x73\x0e\xa3\x51\x0f\xd7\x84\x92\xdc\xc1\xa2\x93\x7f\x27\x39\x3a\x29\x1f\xc8\x91\x14\x8d\xc6\x0b\xb9\x7f\x18\xfa\x80\xc7\xe3\xdf\xe1\x71\x6c\x95\xad\x6b\x8c\x13\x6d\xd0\x61\x88\xc3\xd4\xd1\xbb\x5b\x4c\xb7\xa8\x6f\xa3\xaf\xd0\x40\xeb\xe9\x5a\xae\x6d\x0c\x85\xc1\xba\x9e\x6c\xa4\xbf\x8a\x0d\x65\xd1\xf1\xa2\xb9\xff\x2d\x68\x34\x14\x5a\xd5\x2a\x88\x5a\x99\x36\x44\xaf\xac\xe0\xec\x99\x95\xb7\x9c\xc7\x87\x9e\xd3\xb4\x07\xad\x2a\xe1\xc8\xc7\x15\x7b\x98\xae\x56\x39\x4b\x8b\x5b\x3c\x2f\xb7\xac\x67\xb4\x8d\xec\x82\x89\x18\x82\x67\x4b\xb6\xe1\xe9\x72\x3d\xc0\x66\xdb\xb2\x64\x05\x17\x5f\x9d\x4f\xa5\xe4\xfe\x29\x79\x07\x54\x87\x40\x63\xce\x02\x00\x00")
+
+func _12_guildSettingsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__12_guildSettingsUpSql,
+		"12_guild-settings.up.sql",
+	)
+}
+
+func _12_guildSettingsUpSql() (*asset, error) {
+	bytes, err := _12_guildSettingsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "12_guild-settings.up.sql", size: 718, mode: os.FileMode(493), modTime: time.Unix(1792385008, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __13_multiGuildUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xcd\x59\x5b\x6f\xea\x38\x10\x7e\xcf\xaf\xf0\x5b\x8b\x94\x56\xfb\x8e\xf6\x21\x05\xd3\x45\x0b\x49\x37\x04\x9d\x9e\xa7\xc8\x0d\x26\x44\x85\x84\xcd\xa5\x5d\xfe\xfd\x8e\x2f\xb9\x39\x76\xe9\xa1\x65\xb5\x95\xaa\x0a\xcf\xcd\x33\xf3\xcd\x78\x86\xde\xdd\x21\xfc\x46\xf3\x13\xda\x53\x12\x57\x14\xbd\xd0\x7d\x96\xc6\x05\x2a\x33\x44\xd0\x34\x29\xa2\x2c\xdf\xa0\x82\xe6\xc0\x83\x6e\xe3\x2a\xd9\x6f\x46\xf7\xc8\xcf\xde\x0b\x14\xe5\x94\x94\x74\x03\x12\xdb\x2c\xa7\x52\xbe\x40\xef\x14\x3e\x80\xd8\x91\x91\x4e\xb5\xe8\x8e\xbc\x51\x44\x52\x44\x0f\xc7\xf2\x64\xdd\xdd\x21\xae\x2a\x4c\x36\x36\x2a\x77\xf4\x84\x08\x08\x91\xa2\x48\xe2\x14\xc4\xc0\x36\x1c\xa2\x28\x4b\xb7\x49\x5c\xe5\xb4\xb9\x40\x96\xa2\xa2\x24\x79\x79\xcf\x34\x1c\xf7\xe4\x44\xf3\x02\xb4\x6e\x38\x7b\x49\x5e\xf6\xcc\x7e\x52\xee\xd0\x11\x98\x05\x1d\x55\x69\xf2\x37\xf8\x95\x92\x03\x10\x99\x99\x9c\xbe\x80\xed\x92\x59\x39\x90\xd7\x9a\x22\xd9\x98\xa0\x30\x66\xc3\x5f\x52\x64\xa9\x30\x10\x33\x26\x66\xb5\xab\x81\x9b\x7a\xa5\xa7\x62\xe0\x2f\x84\x88\xf3\x6c\x92\x34\x46\x29\xa5\x9b\x82\x5f\x91\x05\x0a\x5c\x14\x32\x60\xff\x85\xa2\x6c\xbb\x45\xef\xbb\x64\x4f\x39\x43\x4e\xb7\x10\xbe\x34\x62\x41\x10\xee\x80\x3d\x66\x36\xa7\xe0\x0e\x1c\xdf\x5b\x4f\xbe\xf3\xb8\x74\x6a\x55\x21\x57\xf5\x3b\xf2\x66\xb3\xb1\xf5\x80\x1f\xe7\xee\xd8\xb2\x26\x3e\x76\x02\x8c\x02\xe7\x61\x81\xeb\x30\x85\xf2\x8a\xb7\x16\x82\x9f\x64\x83\xe6\x6e\x80\x1f\xb1\x8f\x9e\xfc\xf9\xd2\xf1\x7f\xa2\x3f\xf1\x4f\xe4\xac\x03\x6f\xee\x82\xf8\x12\xbb\x81\xcd\x39\xeb\x3c\xa1\x00\x3f\x07\xc8\xf5\xe0\x77\xbd\x58\xa0\x29\x9e\x39\xeb\x45\x80\x6e\x6e\x04\xdb\x4b\x4c\x06\x4c\x82\xc2\xc2\x6b\x3a\x67\xb7\xe7\x34\x71\xb4\x11\x70\xab\x15\x89\x43\x12\x95\x09\x60\xe7\xc1\xf3\x16\xd8\x71\x87\x37\x08\xfc\x35\x16\x9c\x12\x90\x21\x29\x51\x30\x5f\xe2\x55\xe0\x2c\x9f\x1a\xb6\xc9\xda\xf7\xc1\xa9\xb0\xa5\xf4\xaf\xb3\x76\xe7\x7f\xad\xf1\x6d\x0b\x4b\xe1\xd1\xc8\x40\x65\xb7\x1f\x59\xa3\xb1\x35\x77\x57\xd8\x0f\x58\x34\xbd\x41\xa8\x5b\x35\x42\xc0\x6e\x9c\xb6\x3b\xbe\xda\xd2\x45\xbb\xe3\xc0\xc8\x5a\xe1\x05\x9e\x04\xe8\x72\x15\x68\xe6\x7b\xcb\xfa\x4a\x63\x6b\xea\x7b\x4f\x7d\x44\x8c\x2d\x67\x11\x00\x00\xb4\x30\x81\x58\x39\x4b\x80\x90\xd7\x72\x4b\x54\x89\x50\x80\xc3\x53\xfc\x8c\xe6\x33\x1e\x46\xfc\x3c\x5f\x05\xab\x46\x47\x93\x5a\xcf\xad\xcf\x94\xd0\x31\xea\xe8\x57\x54\x76\xa0\xa1\x55\xda\xd2\x47\xfa\x02\x08\x49\x14\x65\x55\x5a\x5e\xbb\x10\xa4\xb5\x8e\xde\x8b\xcb\xc1\x5c\x53\xdf\x8b\x74\x8e\xe5\xcf\x54\xc1\xcc\xf3\xf1\xfc\xd1\x65\x11\xba\x6d\xfc\x1c\x01\x56\x66\x18\x2c\x4e\x70\x93\x2e\x40\xbe\xa1\x38\x86\x69\x60\x56\x1a\x65\x43\x90\xd7\xe0\x37\xd4\xc6\x2f\x49\x76\x4b\xa2\xb9\x88\xa6\x34\x3a\xb4\x61\x89\x0c\x3c\x50\x4b\xa5\x23\xfd\x59\x7c\xb7\x3a\x87\xa5\xd3\xd0\xf4\x25\xa4\xc5\xfa\x3e\x49\x5f\xc3\x9c\xc2\x83\x56\xa8\x80\xff\x24\x8c\x95\x56\xac\x40\xe8\x1c\xc8\xbf\x84\xcf\x4e\x1d\xea\x0b\xfc\xfb\xc0\xa8\x8f\x53\xb7\xaf\x76\xf0\xa5\x41\xe0\x59\xce\x1e\xe2\x7a\xd6\x74\xb0\x53\x18\x34\xd8\xd3\x5e\x78\x00\x40\x45\x4f\x4f\x51\x4e\xe3\xa4\x28\x73\x52\x26\x59\xda\xf0\x20\x67\x3a\x45\x13\x6f\xb1\x5e\xba\xe7\x01\x22\x6f\xde\xa0\x59\x22\x59\xab\x38\x3c\xd2\x94\xcd\x40\x9f\x29\x85\x0f\x15\xb0\x7a\xd0\x32\xe8\x21\xc2\x11\xf2\xe3\x0f\x40\x02\x1b\x18\xcb\x8a\x8d\x48\x37\x52\x15\x38\xc0\x26\xaa\x95\x3a\xdd\xc9\xd1\xb7\x9e\x3e\xe5\xc8\x59\xee\x20\x8b\xf5\xa8\x0b\xe7\x07\x98\x0b\x33\x49\x84\x19\x18\x46\xda\x9c\x56\x05\x95\xc3\x62\x3d\x66\x82\x4e\x71\x5b\xae\x01\xa8\x70\xcc\x8c\x3e\x3c\x3a\x62\xb0\xbb\x47\x41\x3b\xb2\xd6\x53\x1f\xf3\x93\x99\x00\xb5\x39\x4c\xe4\x4c\xb0\xc9\x46\x92\xb2\xcf\x49\xde\x9b\x21\x6d\x36\x3a\x46\x3b\xe0\x2f\x22\xb2\xa1\xa8\x3a\x6e\xe0\x9e\x05\xbb\xa1\x18\xa6\x41\x23\x33\xcb\x34\x55\xa9\x84\x8b\x1c\xf4\xf9\x80\x2f\x9d\x3c\x64\x6f\x20\x95\x00\x10\xea\x99\xb7\xcc\x62\x0a\x52\xb9\x98\x71\x85\x65\x1e\xa5\xfb\x7e\xc3\x91\xfc\x17\xf5\x18\xd3\x53\x08\x69\x52\x7a\x87\x38\x3f\x24\x69\x28\x32\xa5\x76\x9d\x46\xf5\x6f\x82\x93\xb9\x1e\x56\x47\x0e\x92\x84\xed\x0f\xc6\xe9\x71\xe6\x2c\x56\xf8\x6a\x4d\x4b\x3b\x25\xaa\x21\x13\xef\x56\xed\xb4\xdd\xba\x69\x0f\xfc\xd0\x36\xa1\x0b\xe5\x45\x6b\x92\x97\x51\xdf\x11\x71\x1c\xe6\x15\xc0\xf3\xa2\xdc\x4a\x05\xa6\x14\xbf\x86\x5b\x98\x58\xb3\xdc\xf0\x7e\xf0\x25\x2f\x84\x72\x34\xd0\x81\x12\x6e\xf7\x99\x51\x9e\x85\xa0\x5e\x0f\x0d\x1c\xe4\x1f\x1e\xa4\x90\xc4\x34\xdc\x90\x93\x89\x8f\xec\xf7\xd9\x3b\xc4\x6b\x4b\x52\x7e\x65\xd6\x7a\x0a\x5a\x96\x09\xdb\x91\xbf\x7b\x32\xd3\x83\xa8\x13\x4a\xcd\xd3\xa7\xe7\xeb\x3e\x83\x32\xc5\x2a\x2c\x59\x47\x5d\x3f\x4d\x59\xce\x27\xce\x6a\xe2\x4c\xb1\x1e\xa8\x3a\x10\x74\x4c\xf5\xf3\xa9\xa4\x4f\xc9\xd6\x20\x39\x86\x5c\x9c\x0f\xbd\x1a\x6c\xab\xae\x86\xff\xe9\xf5\x3a\xb5\x26\xe2\x69\x28\xb8\x23\xdc\x2c\x89\x92\x23\xb9\xfe\xa2\x72\xa6\x42\xcf\x8d\x78\xe6\xe2\xe4\xbd\x47\x84\xd0\x24\xbc\x4d\x52\xb2\x0f\x73\x92\xbe\xd6\x0c\xd7\xd8\x6b\xba\x30\x68\xfd\xb9\x6e\x0d\x7d\xc3\x6c\xfa\x11\x14\xcc\x6e\xd9\x2c\x23\x76\x2f\xf8\x76\x27\xce\xc6\x05\xea\xcb\xda\xba\xef\x48\xef\xd2\x2a\xc4\x85\xb2\x4b\x1e\x13\xf3\x22\x7c\x06\xc4\x57\xe8\xc6\xc6\x85\xf8\x8a\x8d\xb8\x1f\xb9\x7a\xaf\xed\x65\x4e\x93\xdd\x33\x7c\x22\x6f\x5c\xb5\x2e\x53\xff\x65\x2b\xe2\xf6\x2e\xdb\x34\xd9\xc4\x4f\x3f\x98\x12\xa2\x1d\x49\xe3\x8f\x18\xe4\xb7\xd7\x57\xd8\x63\x07\xfd\x48\xba\xf9\xd9\x5e\x24\xd9\x7b\x10\xe2\xe9\x1a\xa2\xf1\x2a\x7d\xc8\x8c\x02\xbd\x33\xb6\x48\x86\xdd\x89\xbb\xdd\x09\xb1\xb1\x03\x7d\x49\x53\x8b\x62\xb5\xf7\x74\xd6\x6b\x0d\x59\xa1\xf6\x4f\xb4\xcd\x6c\x48\x97\x0f\xf9\x80\xa0\xac\xee\xca\xbc\xdf\x6e\xeb\x1f\x70\xf7\x87\x2e\x55\xa4\xb6\xac\x91\xd3\xe5\x6b\x20\xde\x77\xac\xab\xa5\xd7\x69\x5a\x39\x19\x22\x95\xf3\x8c\x35\x5d\x56\x60\x19\x8d\xaa\x1c\x96\xdd\x32\x94\x1b\x73\x52\xc8\x75\x9b\x7f\x6a\x37\xef\x9b\x42\xae\xe5\xe2\x3f\x2e\x62\x99\xa6\x6c\x11\xee\xdf\x83\xd7\x42\x33\x92\x77\xbe\xc8\x50\xec\x98\xbf\xce\xb0\x26\xde\x72\x39\x0f\xc6\xa6\x7f\xad\xb8\x63\xeb\x5f\xbf\x3d\xf3\x92\x26\x1b\x00\x00")
+
+func _13_multiGuildUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__13_multiGuildUpSql,
+		"13_multi-guild.up.sql",
+	)
+}
+
+func _13_multiGuildUpSql() (*asset, error) {
+	bytes, err := _13_multiGuildUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "13_multi-guild.up.sql", size: 6950, mode: os.FileMode(493), modTime: time.Unix(1792385238, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __14_managedMessagesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x75\x90\xcf\x6e\x83\x30\x0c\xc6\xef\x3c\xc5\x77\x5b\x2b\xc1\x13\xec\x44\xbb\x74\x42\x03\x56\x85\x20\xb5\x27\x94\x36\x6e\x89\x06\x09\x6a\x42\x9f\x7f\x01\x75\xab\xf6\xa7\x39\x58\xb1\x7f\xb6\x3f\xdb\x49\x82\x5e\x1a\x79\x26\xd5\xf4\xe4\x5c\xf8\x38\x78\x79\xe8\x08\x47\x6b\xbc\xd4\x26\xb8\x2d\xe1\xce\x82\x73\xb0\x1e\x1f\x44\x83\xc3\x38\xc0\x5b\x28\xe9\x29\x86\xbc\xd5\xf9\x56\x7a\x28\x4b\xce\x3c\x79\x9c\xb4\x87\x36\x21\xc7\x9a\xef\x26\xd0\x2e\x4a\x12\xb8\xe1\x42\x52\xc1\x5e\xe9\x02\x47\xc1\xca\x0e\xc3\xa4\x11\xad\x39\x4b\x05\x83\x48\x57\x39\x43\xb6\x41\xf9\x2e\xc0\x76\x59\x25\xaa\xbf\xb3\x2e\x22\x84\x77\x1e\x75\xa7\x1a\xad\x20\xd8\x4e\xcc\xf9\x65\x9d\xe7\xf1\xcc\x8c\xec\xe9\xbf\xf8\xa4\x85\xac\x14\xec\x95\xf1\x5f\xe8\xd8\x4a\x63\xa8\x7b\xd0\xf0\xa6\xfd\x80\x8e\xc3\x74\x0e\xd5\x84\x23\x88\xac\x60\x95\x48\x8b\x2d\x5e\xd8\x26\xad\x73\x81\x75\xcd\x39\x2b\x45\x73\x27\x3f\x8b\xb7\x3c\x2b\x52\xbe\xc7\x1b\xdb\x63\xf1\xb5\x54\x3c\xaf\x10\xcf\x03\x2f\xa3\xe5\x73\xf4\x09\x52\xb1\x44\x70\xb4\x01\x00\x00")
+
+func _14_managedMessagesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__14_managedMessagesUpSql,
+		"14_managed-messages.up.sql",
+	)
+}
+
+func _14_managedMessagesUpSql() (*asset, error) {
+	bytes, err := _14_managedMessagesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "14_managed-messages.up.sql", size: 436, mode: os.FileMode(493), modTime: time.Unix(1792385615, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __15_commandUsesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x75\x4f\xdd\x6a\x83\x30\x14\xbe\xf7\x29\xce\xe5\x06\xf6\x09\xbc\xb2\xdb\x59\x09\x35\xb1\x68\x0a\xf6\x4a\x52\x73\xda\x06\xa2\x82\x3f\xb8\xc7\x5f\xd4\x66\xeb\xca\x96\x8b\x40\xf2\xfd\x6f\x71\xc7\x44\x14\x6c\x36\x50\xb5\x75\xad\x1a\x5d\x8e\x3d\xf5\x30\xa8\xb3\x25\xf7\xd5\x0c\xca\x34\xee\x79\x23\xe8\xc7\xaa\xa2\xbe\xbf\x8c\x16\x16\x4a\x7b\x81\x4e\x0d\x04\xd6\xd4\x66\x20\xed\xf5\x7d\x78\x87\xad\xa6\xce\x09\x55\xb3\xa8\x7f\xa8\x30\x99\x46\xb7\x93\xd3\xcf\xa9\x33\x76\x57\x82\xea\x08\x34\x59\x9a\xdd\xa6\x1b\xad\xc2\x9a\xea\xb3\x33\x5a\x5b\x3d\x92\xaf\xae\x59\xf0\x96\x61\x2c\x11\x64\xbc\x4d\x10\xd8\x07\x88\x54\x02\x16\x2c\x97\xf9\xef\x3d\x2f\x01\xb8\x63\x34\x30\x21\x71\x87\x19\x1c\x32\xc6\xe3\xec\x04\x7b\x3c\x41\x7c\x94\x29\x13\xce\x8a\xa3\x90\xe1\xc2\xbc\x8e\xc6\xea\xd2\xf1\x25\x16\x72\x71\x15\xc7\x24\x59\x31\x67\xd8\xfd\x03\xf9\x72\x7f\xab\x74\xa9\x06\x90\x8c\x63\x2e\x63\x7e\xf8\xc6\x83\xd7\xc8\xef\x60\xe2\x1d\x8b\xa7\x1d\x46\x7f\x96\x8f\x5b\xe6\xab\x83\x54\x3c\x0d\xf4\x8d\x43\xdf\x2f\xf4\x84\xd0\x67\xcf\x39\x29\xe7\x4c\x46\xc1\x17\x3e\x5c\x79\x5b\xf7\x01\x00\x00")
+
+func _15_commandUsesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__15_commandUsesUpSql,
+		"15_command-uses.up.sql",
+	)
+}
+
+func _15_commandUsesUpSql() (*asset, error) {
+	bytes, err := _15_commandUsesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "15_command-uses.up.sql", size: 503, mode: os.FileMode(493), modTime: time.Unix(1792386460, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __16_registrationJobsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x95\x52\x4d\x4f\xe3\x30\x10\xbd\xe7\x57\xcc\x0d\x56\x2a\x88\x7b\x4f\x81\x1a\x64\x6d\x93\xa2\xd4\x95\xca\x29\x72\xed\xa1\x78\x9b\xd8\xc1\x76\xc4\xfe\xfc\x9d\x38\x01\x04\x6d\x91\x36\x97\xc8\x7e\x1f\x63\x3f\xbf\x5b\xf6\xc0\xcb\x79\x76\x75\x05\x1e\xf7\x26\x44\x2f\xa3\x71\xb6\xfe\xe3\x76\x01\xa2\xdc\x35\x08\xca\xd9\x28\x8d\xa5\xe5\x0b\xc2\x5e\xb6\x18\xa0\xc5\x76\x87\x3e\x80\x0c\x07\xd4\x10\xdd\xa4\x45\x3f\x03\x09\x6f\xce\x1f\xd0\x03\x19\x61\xd2\xb4\xe0\x2c\x82\x8c\x04\x45\xd3\xe2\xf5\x30\x4b\x76\x5d\x6d\x34\x48\xab\xc1\x58\xd2\x49\x95\xa6\x46\x77\x40\x0b\x0d\xc6\x34\x6b\x32\xea\x3b\x4d\x56\x69\xc7\x63\xe8\x9c\x0d\x08\xee\x39\xad\x95\x6b\xdb\xc1\xe3\xd9\x79\x3a\x0b\x34\xce\xee\x87\xff\xc2\x04\xe5\x3c\xd9\x2b\x85\x5d\x4c\x87\x18\x86\x26\xf7\x59\x12\x8e\x83\x4c\x00\xd5\xa0\xf4\x74\x07\x67\xd5\x38\x82\x2e\x3e\xec\x6b\x3a\xf3\x75\x76\x57\xb1\x5c\x30\x10\xf9\xed\x92\x01\xbf\x87\x72\x25\x80\x6d\xf9\x5a\xac\x4f\xa4\x75\x99\x01\x7d\x74\x2b\x5e\x0a\xf6\xc0\x2a\x78\xac\x78\x91\x57\x4f\xf0\x9b\x3d\x41\xbe\x11\x2b\x5e\x92\x5f\xc1\x4a\x31\x4b\xcc\x7d\x6f\x1a\x3d\xa4\x20\xd8\x56\x24\xeb\x72\xb3\x5c\x4e\x18\xc5\x5c\x37\xc6\x1e\x4e\x81\x7d\x40\x7f\x46\xa7\x5e\xa4\xb5\xd8\x9c\x41\xa7\xd4\xbf\x20\xb0\x60\xf7\xf9\x66\x29\xe0\xe2\x62\x24\x1d\xbf\xc7\xcf\xfc\x10\x65\xec\xc3\x39\xd2\x6b\x8f\x3d\xea\x89\x2a\x63\xc4\x76\x78\x90\xf7\x84\x8e\xf8\x37\x23\x11\xbd\xa7\x17\xfd\x79\xae\xf2\x48\xb5\xd0\x35\x15\x4b\xf0\x82\xad\x45\x5e\x3c\x7e\x90\xee\x36\x55\x45\x41\xd7\x9f\xc8\xb7\x08\x53\xa7\xfe\x5b\x9c\xfd\x9a\xbf\x77\x82\x97\x0b\xb6\xfd\xd6\x09\xa3\xff\xd6\x47\xbd\xa8\xa7\x80\x56\xe5\xa9\xce\x8c\xe0\x8c\xa4\x83\xf5\xaa\x28\xb8\x98\x67\xff\x00\x62\x44\xaa\xdc\x94\x03\x00\x00")
+
+func _16_registrationJobsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__16_registrationJobsUpSql,
+		"16_registration-jobs.up.sql",
+	)
+}
+
+func _16_registrationJobsUpSql() (*asset, error) {
+	bytes, err := _16_registrationJobsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "16_registration-jobs.up.sql", size: 916, mode: os.FileMode(493), modTime: time.Unix(1792386656, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __17_voidGamesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8d\x8e\xb1\x8e\xc2\x30\x10\x44\xfb\x7c\xc5\x74\x34\xf0\x05\xa9\x0c\xf6\xa1\x48\x76\x82\xc0\x91\xae\x43\xe6\xbc\x90\x08\x88\x91\x6d\x40\xfc\x3d\x4e\x14\x09\x2a\xb8\x62\x8b\x9d\x9d\x9d\x79\x73\xb1\x2c\xca\x3c\x9b\xcd\x70\x73\xad\x25\xbb\x35\x11\x6d\x40\xa0\x88\x7b\x43\x1d\x0c\xce\xce\x92\x37\xd1\xf9\xc1\x11\x92\x72\x30\x67\x9a\x8e\xfe\x61\x49\xa2\x27\x1c\xe9\x12\xb1\x4f\x3e\x73\xb5\x6d\xc4\xee\x1a\xd1\x39\x9c\x5c\x77\x20\x8f\x94\x40\x36\x63\x52\x8b\x35\x34\x9b\x4b\x31\x3e\x32\xce\xb1\xa8\x64\xad\xca\x37\x00\x5d\x28\xb1\xd1\x4c\xad\xde\xc1\x76\x8f\x1e\x2c\x36\x04\xde\x86\x3f\xe7\x2d\x0a\x0e\xb7\x1f\x94\x17\x63\x6c\xd2\xff\x88\xd6\x5f\xfa\x96\x7f\xd5\xa6\x78\x2d\x7e\x35\xca\x2a\x4d\x2d\x25\xb8\xf8\x61\xb5\xd4\x98\x4c\xf2\xef\x01\x5b\x4f\x26\xb8\xee\x43\xc4\xa2\x52\xaa\xd0\x79\xf6\x04\x60\x50\x24\x55\x71\x01\x00\x00")
+
+func _17_voidGamesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__17_voidGamesUpSql,
+		"17_void-games.up.sql",
+	)
+}
+
+func _17_voidGamesUpSql() (*asset, error) {
+	bytes, err := _17_voidGamesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "17_void-games.up.sql", size: 369, mode: os.FileMode(493), modTime: time.Unix(1792387170, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -163,6 +478,21 @@ func AssetNames() []string {
 var _bindata = map[string]func() (*asset, error){
 	"1_create-tables.up.sql": _1_createTablesUpSql,
 	"2_create-s1.up.sql": _2_createS1UpSql,
+	"3_season-end.up.sql": _3_seasonEndUpSql,
+	"4_season-min-games.up.sql": _4_seasonMinGamesUpSql,
+	"5_season-rules.up.sql": _5_seasonRulesUpSql,
+	"6_season-sign-up.up.sql": _6_seasonSignUpUpSql,
+	"7_player-discord-link.up.sql": _7_playerDiscordLinkUpSql,
+	"8_registration-requests.up.sql": _8_registrationRequestsUpSql,
+	"9_player-active.up.sql": _9_playerActiveUpSql,
+	"10_player-accounts.up.sql": _10_playerAccountsUpSql,
+	"11_player-name-key.up.sql": _11_playerNameKeyUpSql,
+	"12_guild-settings.up.sql": _12_guildSettingsUpSql,
+	"13_multi-guild.up.sql": _13_multiGuildUpSql,
+	"14_managed-messages.up.sql": _14_managedMessagesUpSql,
+	"15_command-uses.up.sql": _15_commandUsesUpSql,
+	"16_registration-jobs.up.sql": _16_registrationJobsUpSql,
+	"17_void-games.up.sql": _17_voidGamesUpSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -207,6 +537,21 @@ type bintree struct {
 var _bintree = &bintree{nil, map[string]*bintree{
 	"1_create-tables.up.sql": &bintree{_1_createTablesUpSql, map[string]*bintree{}},
 	"2_create-s1.up.sql": &bintree{_2_createS1UpSql, map[string]*bintree{}},
+	"3_season-end.up.sql": &bintree{_3_seasonEndUpSql, map[string]*bintree{}},
+	"4_season-min-games.up.sql": &bintree{_4_seasonMinGamesUpSql, map[string]*bintree{}},
+	"5_season-rules.up.sql": &bintree{_5_seasonRulesUpSql, map[string]*bintree{}},
+	"6_season-sign-up.up.sql": &bintree{_6_seasonSignUpUpSql, map[string]*bintree{}},
+	"7_player-discord-link.up.sql": &bintree{_7_playerDiscordLinkUpSql, map[string]*bintree{}},
+	"8_registration-requests.up.sql": &bintree{_8_registrationRequestsUpSql, map[string]*bintree{}},
+	"9_player-active.up.sql": &bintree{_9_playerActiveUpSql, map[string]*bintree{}},
+	"10_player-accounts.up.sql": &bintree{_10_playerAccountsUpSql, map[string]*bintree{}},
+	"11_player-name-key.up.sql": &bintree{_11_playerNameKeyUpSql, map[string]*bintree{}},
+	"12_guild-settings.up.sql": &bintree{_12_guildSettingsUpSql, map[string]*bintree{}},
+	"13_multi-guild.up.sql": &bintree{_13_multiGuildUpSql, map[string]*bintree{}},
+	"14_managed-messages.up.sql": &bintree{_14_managedMessagesUpSql, map[string]*bintree{}},
+	"15_command-uses.up.sql": &bintree{_15_commandUsesUpSql, map[string]*bintree{}},
+	"16_registration-jobs.up.sql": &bintree{_16_registrationJobsUpSql, map[string]*bintree{}},
+	"17_void-games.up.sql": &bintree{_17_voidGamesUpSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory
@@ -0,0 +1,9 @@
+-- player_accounts table contains the additional BGA accounts of players that play from more than one account
+CREATE TABLE IF NOT EXISTS player_accounts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    player_id INTEGER NOT NULL,
+    name TEXT NOT NULL UNIQUE,
+    bga_id TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    FOREIGN KEY(player_id) REFERENCES players(id)
+);
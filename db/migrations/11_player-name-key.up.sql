@@ -0,0 +1,18 @@
+BEGIN;
+-- name_key is the name normalized for matching (Unicode NFC, case folded), names are unique by their key.
+-- Names that only differ in case or encoding get the same key: the oldest row keeps its name and the others get their
+-- ID appended, so the unique indexes can be created and a moderator can rename or merge them afterwards.
+ALTER TABLE players ADD COLUMN name_key TEXT;
+UPDATE players SET name_key = normalize_name(name);
+UPDATE players
+SET name = name || ' (' || id || ')', name_key = normalize_name(name || ' (' || id || ')')
+WHERE id NOT IN (SELECT MIN(id) FROM players GROUP BY name_key);
+CREATE UNIQUE INDEX IF NOT EXISTS players_name_key ON players(name_key);
+
+ALTER TABLE player_accounts ADD COLUMN name_key TEXT;
+UPDATE player_accounts SET name_key = normalize_name(name);
+UPDATE player_accounts
+SET name = name || ' (' || id || ')', name_key = normalize_name(name || ' (' || id || ')')
+WHERE id NOT IN (SELECT MIN(id) FROM player_accounts GROUP BY name_key);
+CREATE UNIQUE INDEX IF NOT EXISTS player_accounts_name_key ON player_accounts(name_key);
+COMMIT;
@@ -0,0 +1,14 @@
+-- guild_settings table contains the settings of each Discord server, channels and roles that are not set are looked up
+-- by their default name
+CREATE TABLE IF NOT EXISTS guild_settings (
+    guild_id TEXT PRIMARY KEY,
+    games_channel_id TEXT NOT NULL DEFAULT '',
+    leaderboard_channel_id TEXT NOT NULL DEFAULT '',
+    registered_players_channel_id TEXT NOT NULL DEFAULT '',
+    staff_channel_id TEXT NOT NULL DEFAULT '',
+    -- comma separated list of the roles that can use the moderator commands
+    moderator_role_ids TEXT NOT NULL DEFAULT '',
+    rate_limit_minutes INTEGER NOT NULL DEFAULT 60,
+    public_results BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
@@ -0,0 +1,171 @@
+-- Every league belongs to a Discord server (guild). Rows created before leagues were scoped by server have an empty
+-- guild_id, they are assigned to the configured server on start.
+-- players and the tables with per player unique names are rebuilt to make names unique per server, seasons and games
+-- are rebuilt with keys scoped by server. Rebuilding needs the foreign keys to be off while the referenced tables are
+-- replaced.
+PRAGMA foreign_keys = OFF;
+BEGIN;
+
+CREATE TABLE players_scoped (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    guild_id TEXT NOT NULL DEFAULT '',
+    bga_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    name_key TEXT,
+    discord_id TEXT,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    UNIQUE(guild_id, bga_id),
+    UNIQUE(guild_id, name)
+);
+INSERT INTO players_scoped (id, bga_id, name, name_key, discord_id, active, created_at)
+SELECT id, bga_id, name, name_key, discord_id, active, created_at FROM players;
+DROP TABLE players;
+ALTER TABLE players_scoped RENAME TO players;
+CREATE UNIQUE INDEX IF NOT EXISTS players_name_key ON players(guild_id, name_key);
+CREATE UNIQUE INDEX IF NOT EXISTS players_discord_id ON players(guild_id, discord_id);
+
+CREATE TABLE player_accounts_scoped (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    guild_id TEXT NOT NULL DEFAULT '',
+    player_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    name_key TEXT,
+    bga_id TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    UNIQUE(guild_id, name),
+    UNIQUE(guild_id, bga_id),
+    FOREIGN KEY(player_id) REFERENCES players(id)
+);
+INSERT INTO player_accounts_scoped (id, player_id, name, name_key, bga_id, created_at)
+SELECT id, player_id, name, name_key, bga_id, created_at FROM player_accounts;
+DROP TABLE player_accounts;
+ALTER TABLE player_accounts_scoped RENAME TO player_accounts;
+CREATE UNIQUE INDEX IF NOT EXISTS player_accounts_name_key ON player_accounts(guild_id, name_key);
+
+CREATE TABLE player_link_requests_scoped (
+    guild_id TEXT NOT NULL DEFAULT '',
+    discord_id TEXT NOT NULL,
+    player_id INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY(guild_id, discord_id),
+    FOREIGN KEY(player_id) REFERENCES players(id)
+);
+INSERT INTO player_link_requests_scoped (discord_id, player_id, created_at)
+SELECT discord_id, player_id, created_at FROM player_link_requests;
+DROP TABLE player_link_requests;
+ALTER TABLE player_link_requests_scoped RENAME TO player_link_requests;
+
+ALTER TABLE registration_requests ADD COLUMN guild_id TEXT NOT NULL DEFAULT '';
+DROP INDEX IF EXISTS registration_requests_pending;
+CREATE UNIQUE INDEX IF NOT EXISTS registration_requests_pending ON registration_requests(guild_id, discord_id)
+    WHERE status = 'pending';
+
+-- Seasons and games belong to the server that created them, so servers can reuse season names and register the same
+-- BGA table. The tables referencing them carry the guild_id in their foreign keys, which cascade updates so assigning
+-- the unscoped league to a server moves its seasons together with their games.
+CREATE TABLE seasons_scoped (
+    guild_id TEXT NOT NULL DEFAULT '',
+    name TEXT NOT NULL,
+    ended_at TIMESTAMP,
+    min_games INTEGER NOT NULL DEFAULT 0,
+    sign_up_required BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY(guild_id, name)
+);
+INSERT INTO seasons_scoped (name, ended_at, min_games, sign_up_required, created_at)
+SELECT name, ended_at, min_games, sign_up_required, created_at FROM seasons;
+
+CREATE TABLE season_rules_scoped (
+    guild_id TEXT NOT NULL DEFAULT '',
+    season_name TEXT NOT NULL,
+    k_factor INTEGER NOT NULL,
+    start_elo INTEGER NOT NULL,
+    elo_floor INTEGER NOT NULL,
+    min_players INTEGER NOT NULL,
+    max_game_age_days INTEGER NOT NULL,
+    allowed_fan_faction_settings TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY(guild_id, season_name),
+    FOREIGN KEY(guild_id, season_name) REFERENCES seasons(guild_id, name) ON UPDATE CASCADE
+);
+INSERT INTO season_rules_scoped (
+    season_name,
+    k_factor,
+    start_elo,
+    elo_floor,
+    min_players,
+    max_game_age_days,
+    allowed_fan_faction_settings,
+    created_at
+)
+SELECT
+    season_name,
+    k_factor,
+    start_elo,
+    elo_floor,
+    min_players,
+    max_game_age_days,
+    allowed_fan_faction_settings,
+    created_at
+FROM season_rules;
+
+CREATE TABLE season_participants_scoped (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    guild_id TEXT NOT NULL DEFAULT '',
+    season_name TEXT NOT NULL,
+    player_id INTEGER NOT NULL,
+    elo INTEGER NOT NULL,
+    games_played INTEGER NOT NULL,
+    final_rank INTEGER,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    UNIQUE(guild_id, season_name, player_id),
+    FOREIGN KEY(guild_id, season_name) REFERENCES seasons(guild_id, name) ON UPDATE CASCADE,
+    FOREIGN KEY(player_id) REFERENCES players(id)
+);
+INSERT INTO season_participants_scoped (id, season_name, player_id, elo, games_played, final_rank, created_at)
+SELECT id, season_name, player_id, elo, games_played, final_rank, created_at FROM season_participants;
+
+CREATE TABLE games_scoped (
+    guild_id TEXT NOT NULL DEFAULT '',
+    bga_id TEXT NOT NULL,
+    season_name TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY(guild_id, bga_id),
+    FOREIGN KEY(guild_id, season_name) REFERENCES seasons(guild_id, name) ON UPDATE CASCADE
+);
+INSERT INTO games_scoped (bga_id, season_name, created_at)
+SELECT bga_id, season_name, created_at FROM games;
+
+CREATE TABLE game_participants_scoped (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    guild_id TEXT NOT NULL DEFAULT '',
+    game_id TEXT NOT NULL,
+    player_id INTEGER NOT NULL,
+    score INTEGER NOT NULL,
+    elo_change INTEGER NOT NULL,
+    elo_before INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    UNIQUE(guild_id, game_id, player_id),
+    FOREIGN KEY(guild_id, game_id) REFERENCES games(guild_id, bga_id) ON UPDATE CASCADE,
+    FOREIGN KEY(player_id) REFERENCES players(id)
+);
+INSERT INTO game_participants_scoped (id, game_id, player_id, score, elo_change, elo_before, created_at)
+SELECT id, game_id, player_id, score, elo_change, elo_before, created_at FROM game_participants;
+
+DROP TABLE game_participants;
+DROP TABLE games;
+DROP TABLE season_participants;
+DROP TABLE season_rules;
+DROP TABLE seasons;
+ALTER TABLE seasons_scoped RENAME TO seasons;
+ALTER TABLE season_rules_scoped RENAME TO season_rules;
+ALTER TABLE season_participants_scoped RENAME TO season_participants;
+ALTER TABLE games_scoped RENAME TO games;
+ALTER TABLE game_participants_scoped RENAME TO game_participants;
+
+-- current_season is the season the server's games are registered in
+ALTER TABLE guild_settings ADD COLUMN current_season TEXT NOT NULL DEFAULT '';
+
+COMMIT;
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,11 @@
+-- managed_messages table contains the messages the bot keeps up to date, a table that doesn't fit into one message is
+-- spread over several pages
+CREATE TABLE IF NOT EXISTS managed_messages (
+    guild_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    page INTEGER NOT NULL,
+    channel_id TEXT NOT NULL,
+    message_id TEXT NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (guild_id, name, page)
+);
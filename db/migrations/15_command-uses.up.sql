@@ -0,0 +1,12 @@
+BEGIN;
+-- command_uses table contains the successful uses of rate limited commands, uses older than the rate limit window of
+-- the command are deleted when the member uses the command again
+CREATE TABLE IF NOT EXISTS command_uses (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    guild_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    command TEXT NOT NULL,
+    used_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_command_uses_user ON command_uses (guild_id, user_id, command, used_at);
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+-- registration_jobs table contains the games members asked to register, a worker rates them one at a time.
+-- app_id and interaction_token let the worker update the response of the command for as long as Discord accepts the
+-- token, the token is cleared once the job is done.
+CREATE TABLE IF NOT EXISTS registration_jobs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    guild_id TEXT NOT NULL,
+    game_link TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    channel_id TEXT NOT NULL,
+    app_id TEXT NOT NULL DEFAULT '',
+    interaction_token TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'queued',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_registration_jobs_status ON registration_jobs (status, id);
+COMMIT;
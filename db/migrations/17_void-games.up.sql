@@ -0,0 +1,7 @@
+BEGIN;
+-- voided_at is set when a moderator voids a game, voided games are kept for audit but no longer rated
+ALTER TABLE games ADD COLUMN voided_at TIMESTAMP;
+-- voided_by is the Discord ID of the moderator that voided the game
+ALTER TABLE games ADD COLUMN voided_by TEXT NOT NULL DEFAULT '';
+ALTER TABLE games ADD COLUMN void_reason TEXT NOT NULL DEFAULT '';
+COMMIT;
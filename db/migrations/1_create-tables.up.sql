@@ -1,3 +1,4 @@
+BEGIN;
 -- players table contains all players that participate in the league
 CREATE TABLE IF NOT EXISTS players (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -46,3 +47,4 @@ CREATE TABLE IF NOT EXISTS game_participants (
     FOREIGN KEY(game_id) REFERENCES games(bga_id),
     FOREIGN KEY(player_id) REFERENCES players(id)
 );
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+-- ended_at is set when a season is closed, after which no more games can be registered
+ALTER TABLE seasons ADD COLUMN ended_at TIMESTAMP;
+
+-- final_rank archives the final standings of a closed season
+ALTER TABLE season_participants ADD COLUMN final_rank INTEGER;
+COMMIT;
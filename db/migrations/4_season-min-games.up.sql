@@ -0,0 +1,2 @@
+-- min_games is the number of games a player has to play in a season to be ranked in the final standings
+ALTER TABLE seasons ADD COLUMN min_games INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,16 @@
+-- season_rules table contains the rules a season is rated by, they are set when the season is created
+CREATE TABLE IF NOT EXISTS season_rules (
+    season_name TEXT PRIMARY KEY,
+    k_factor INTEGER NOT NULL,
+    start_elo INTEGER NOT NULL,
+    elo_floor INTEGER NOT NULL,
+    min_players INTEGER NOT NULL,
+    max_game_age_days INTEGER NOT NULL,
+    -- comma separated list of the fan faction settings a game can be played with
+    allowed_fan_faction_settings TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    FOREIGN KEY(season_name) REFERENCES seasons(name)
+);
+
+-- The rules of the seasons created before this table are seeded at startup from the eloKFactor and maxGameAgeDays
+-- config values they were rated by
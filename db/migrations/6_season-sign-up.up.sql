@@ -0,0 +1,2 @@
+-- sign_up_required restricts rated games to players that have joined the season
+ALTER TABLE seasons ADD COLUMN sign_up_required BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,13 @@
+BEGIN;
+-- discord_id links a player to the Discord account that owns it
+ALTER TABLE players ADD COLUMN discord_id TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS players_discord_id ON players(discord_id);
+
+-- player_link_requests table contains the links that are waiting for a moderator to approve them
+CREATE TABLE IF NOT EXISTS player_link_requests (
+    discord_id TEXT PRIMARY KEY,
+    player_id INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    FOREIGN KEY(player_id) REFERENCES players(id)
+);
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+-- registration_requests table contains the players that registered themselves, a moderator approves or rejects them
+CREATE TABLE IF NOT EXISTS registration_requests (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    discord_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    bga_id TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    reviewed_by TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS registration_requests_pending ON registration_requests(discord_id)
+    WHERE status = 'pending';
+COMMIT;
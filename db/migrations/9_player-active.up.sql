@@ -0,0 +1,2 @@
+-- active is false for retired players, their games are no longer rated
+ALTER TABLE players ADD COLUMN active BOOLEAN NOT NULL DEFAULT TRUE;
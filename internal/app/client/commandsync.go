@@ -0,0 +1,124 @@
+package client
+
+import (
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// CommandChanges are the names of the commands that have to change to bring the commands registered in a guild up to
+// date.
+type CommandChanges struct {
+	Created []string
+	Updated []string
+	Deleted []string
+}
+
+func (c *CommandChanges) Empty() bool {
+	return len(c.Created) == 0 && len(c.Updated) == 0 && len(c.Deleted) == 0
+}
+
+func (c *CommandChanges) String() string {
+	return fmt.Sprintf("created %v, updated %v, deleted %v", c.Created, c.Updated, c.Deleted)
+}
+
+// DiffCommands compares the commands the bot declares with the commands registered in a guild. Commands are matched
+// by name, fields Discord fills in like the ID and the version are ignored.
+func DiffCommands(desired, registered []*discordgo.ApplicationCommand) *CommandChanges {
+	changes := &CommandChanges{}
+	registeredByName := make(map[string]*discordgo.ApplicationCommand, len(registered))
+	for _, command := range registered {
+		registeredByName[command.Name] = command
+	}
+	for _, command := range desired {
+		registeredCommand, ok := registeredByName[command.Name]
+		switch {
+		case !ok:
+			changes.Created = append(changes.Created, command.Name)
+		case !equalCommands(command, registeredCommand):
+			changes.Updated = append(changes.Updated, command.Name)
+		}
+		delete(registeredByName, command.Name)
+	}
+	for _, command := range registered {
+		if _, ok := registeredByName[command.Name]; ok {
+			changes.Deleted = append(changes.Deleted, command.Name)
+		}
+	}
+	return changes
+}
+
+func equalCommands(a, b *discordgo.ApplicationCommand) bool {
+	return a.Name == b.Name && a.Description == b.Description && equalOptions(a.Options, b.Options)
+}
+
+// equalOptions compares options the way Discord stores them, Discord leaves out empty lists.
+func equalOptions(a, b []*discordgo.ApplicationCommandOption) bool {
+	return slices.EqualFunc(a, b, func(x, y *discordgo.ApplicationCommandOption) bool {
+		return x.Type == y.Type &&
+			x.Name == y.Name &&
+			x.Description == y.Description &&
+			x.Required == y.Required &&
+			x.Autocomplete == y.Autocomplete &&
+			slices.Equal(x.ChannelTypes, y.ChannelTypes) &&
+			equalValues(x.MinValue, y.MinValue) &&
+			x.MaxValue == y.MaxValue &&
+			equalValues(x.MinLength, y.MinLength) &&
+			x.MaxLength == y.MaxLength &&
+			equalChoices(x.Choices, y.Choices) &&
+			equalOptions(x.Options, y.Options)
+	})
+}
+
+func equalChoices(a, b []*discordgo.ApplicationCommandOptionChoice) bool {
+	return slices.EqualFunc(a, b, func(x, y *discordgo.ApplicationCommandOptionChoice) bool {
+		// Discord returns the values of integer choices as floats
+		return x.Name == y.Name && fmt.Sprint(x.Value) == fmt.Sprint(y.Value)
+	})
+}
+
+func equalValues[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// syncCommands brings the commands registered in the guild up to date. Nothing is sent when the commands are already
+// up to date, otherwise every command is overwritten in a single request which also deletes the obsolete commands.
+func (d *Discord) syncCommands(s *discordgo.Session, guildID string, commands []*discordgo.ApplicationCommand) error {
+	registered, err := s.ApplicationCommands(d.conf.Discord.AppID, guildID)
+	if err != nil {
+		return errors.Wrap(err, "could not get registered commands")
+	}
+
+	changes := DiffCommands(commands, registered)
+	if changes.Empty() {
+		log.Printf("commands in guild %s are up to date", guildID)
+		return nil
+	}
+	log.Printf("syncing commands in guild %s: %s", guildID, changes)
+	_, err = s.ApplicationCommandBulkOverwrite(d.conf.Discord.AppID, guildID, commands)
+	if err != nil {
+		return errors.Wrap(err, "could not overwrite commands")
+	}
+	return nil
+}
+
+// removeCommands deletes the commands of the bot from every guild it is in.
+func (d *Discord) removeCommands() {
+	for _, guild := range d.Client.State.Guilds {
+		// Overwriting with an empty list deletes every command, nil would be sent as null
+		_, err := d.Client.ApplicationCommandBulkOverwrite(
+			d.conf.Discord.AppID,
+			guild.ID,
+			[]*discordgo.ApplicationCommand{},
+		)
+		if err != nil {
+			log.Printf("could not remove commands from guild %s: %v", guild.ID, err)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package client_test
+
+import (
+	"testing"
+	"tmff-discord-app/internal/app/client"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffCommands(t *testing.T) {
+	t.Parallel()
+	minValue := 0.0
+	desired := []*discordgo.ApplicationCommand{
+		{
+			Name:        "leaderboard",
+			Description: "Show the leaderboard of the current season.",
+		},
+		{
+			Name:        "set-min-games",
+			Description: "Set the minimum number of games.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "games",
+					Description: "The minimum number of games.",
+					Required:    true,
+					MinValue:    &minValue,
+				},
+			},
+		},
+		{
+			Name:        "create-season",
+			Description: "Create a new season.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "fan-factions",
+					Description: "The fan faction settings games can be played with.",
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "Both", Value: "both"},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("Test registered commands are up to date", func(t *testing.T) {
+		t.Parallel()
+		registeredMinValue := 0.0
+		// Discord fills in IDs and leaves out empty lists
+		registered := []*discordgo.ApplicationCommand{
+			{
+				ID:          "1",
+				Version:     "1",
+				Type:        discordgo.ChatApplicationCommand,
+				Name:        "leaderboard",
+				Description: "Show the leaderboard of the current season.",
+				Options:     []*discordgo.ApplicationCommandOption{},
+			},
+			{
+				ID:          "2",
+				Name:        "set-min-games",
+				Description: "Set the minimum number of games.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:         discordgo.ApplicationCommandOptionInteger,
+						Name:         "games",
+						Description:  "The minimum number of games.",
+						Required:     true,
+						MinValue:     &registeredMinValue,
+						ChannelTypes: []discordgo.ChannelType{},
+					},
+				},
+			},
+			{
+				ID:          "3",
+				Name:        "create-season",
+				Description: "Create a new season.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "fan-factions",
+						Description: "The fan faction settings games can be played with.",
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Both", Value: "both"},
+						},
+					},
+				},
+			},
+		}
+
+		changes := client.DiffCommands(desired, registered)
+		assert.True(t, changes.Empty(), changes.String())
+	})
+
+	t.Run("Test changed commands", func(t *testing.T) {
+		t.Parallel()
+		registered := []*discordgo.ApplicationCommand{
+			{
+				ID:          "1",
+				Name:        "leaderboard",
+				Description: "Show the leaderboard.",
+			},
+			{
+				ID:          "2",
+				Name:        "set-min-games",
+				Description: "Set the minimum number of games.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "games",
+						Description: "The minimum number of games.",
+						Required:    true,
+					},
+				},
+			},
+			{
+				ID:          "4",
+				Name:        "register-games",
+				Description: "The old name of a command.",
+			},
+		}
+
+		changes := client.DiffCommands(desired, registered)
+		assert.False(t, changes.Empty())
+		assert.Equal(t, []string{"create-season"}, changes.Created)
+		assert.Equal(t, []string{"leaderboard", "set-min-games"}, changes.Updated)
+		assert.Equal(t, []string{"register-games"}, changes.Deleted)
+	})
+
+	t.Run("Test nothing registered yet", func(t *testing.T) {
+		t.Parallel()
+		changes := client.DiffCommands(desired, nil)
+		assert.Equal(t, []string{"leaderboard", "set-min-games", "create-season"}, changes.Created)
+		assert.Empty(t, changes.Updated)
+		assert.Empty(t, changes.Deleted)
+	})
+}
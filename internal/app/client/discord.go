@@ -2,6 +2,7 @@ package client
 
 import (
 	"log"
+	"strings"
 	"time"
 	"tmff-discord-app/internal/app/config"
 
@@ -25,30 +26,47 @@ func NewDiscord(conf *config.Config) (*Discord, error) {
 	}, nil
 }
 
+// Initialize registers the commands in every guild the bot is in or joins later, guildReady is called once the
+// commands of a guild are registered. Every command is handled by commandHandler.
 func (d *Discord) Initialize(
 	commands []*discordgo.ApplicationCommand,
-	commandHandlers map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate),
+	commandHandler func(s *discordgo.Session, i *discordgo.InteractionCreate),
+	componentHandlers map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate),
+	guildReady func(s *discordgo.Session, guildID string),
 ) error {
-	err := d.Client.Open()
-	if err != nil {
-		return errors.Wrap(err, "could not open discord connection")
-	}
-
 	d.Client.AddHandler(func(s *discordgo.Session, _ *discordgo.Ready) {
 		log.Printf("Logged in as: %v#%v", s.State.User.Username, s.State.User.Discriminator)
 	})
 
-	d.Client.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-		if handler, ok := commandHandlers[i.ApplicationCommandData().Name]; ok {
-			handler(s, i)
+	// Discord sends a guild create event for every guild when connecting and whenever the bot joins a guild
+	d.Client.AddHandler(func(s *discordgo.Session, g *discordgo.GuildCreate) {
+		if g.Unavailable {
+			return
+		}
+		err := d.syncCommands(s, g.ID, commands)
+		if err != nil {
+			log.Printf("could not sync commands in guild %s: %v", g.ID, err)
 		}
+		guildReady(s, g.ID)
 	})
 
-	for _, command := range commands {
-		_, err = d.Client.ApplicationCommandCreate(d.conf.Discord.AppID, d.conf.Discord.GuildID, command)
-		if err != nil {
-			return errors.Wrap(err, "could not create application command")
+	d.Client.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		switch i.Type {
+		case discordgo.InteractionApplicationCommand:
+			commandHandler(s, i)
+		case discordgo.InteractionMessageComponent:
+			// Component custom IDs are "<handler>:<argument>"
+			handlerName, _, _ := strings.Cut(i.MessageComponentData().CustomID, ":")
+			if handler, ok := componentHandlers[handlerName]; ok {
+				handler(s, i)
+			}
+		default:
 		}
+	})
+
+	err := d.Client.Open()
+	if err != nil {
+		return errors.Wrap(err, "could not open discord connection")
 	}
 
 	return nil
@@ -58,7 +76,11 @@ func (d *Discord) UpdateLeaderboard() (string, error) {
 	return "", nil
 }
 
+// Close disconnects from Discord, the commands of the bot are removed first when the config asks for it.
 func (d *Discord) Close() error {
+	if d.conf.Discord.RemoveCommandsOnExit {
+		d.removeCommands()
+	}
 	return d.Client.Close()
 }
 
@@ -8,19 +8,24 @@ import (
 )
 
 type Config struct {
-	QueryTimeout   string        `yaml:"queryTimeout"`
-	DBFile         string        `yaml:"dbFile"`
-	MaxGameAgeDays int           `yaml:"maxGameAgeDays"`
-	CurrentSeason  string        `yaml:"currentSeason"`
+	QueryTimeout string `yaml:"queryTimeout"`
+	DBFile       string `yaml:"dbFile"`
+	// CurrentSeason seeds the current season of the guild in GuildID, other guilds start with their first season
+	CurrentSeason string `yaml:"currentSeason"`
+	// EloKFactor and MaxGameAgeDays seed the rules of the seasons created before the rules were stored per season
 	EloKFactor     int           `yaml:"eloKFactor"`
+	MaxGameAgeDays int           `yaml:"maxGameAgeDays"`
 	Discord        DiscordConfig `yaml:"discord"`
 }
 
 type DiscordConfig struct {
-	AppID     string `yaml:"appID"`
-	Token     string `yaml:"token"`
+	AppID string `yaml:"appID"`
+	Token string `yaml:"token"`
+	// GuildID is the guild that owns the league kept before the bot supported several guilds
 	GuildID   string `yaml:"guildID"`
 	PublicKey string `yaml:"publicKey"`
+	// RemoveCommandsOnExit removes the commands from every guild when the bot shuts down
+	RemoveCommandsOnExit bool `yaml:"removeCommandsOnExit"`
 }
 
 func ReadConfig(configFile string) (*Config, error) {
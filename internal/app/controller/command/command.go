@@ -0,0 +1,92 @@
+package command
+
+import (
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// ErrNoHandler is returned for commands that are declared without a handler.
+var ErrNoHandler = errors.New("command has no handler")
+
+// Permission is who can use a command.
+type Permission int
+
+const (
+	// Everyone can use the command.
+	Everyone Permission = iota
+	// Moderator commands can only be used by members with a moderator role.
+	Moderator
+)
+
+// RateLimit is how often a member can use a command, the zero value is unlimited. Commands without a window use the
+// window the guild configured.
+type RateLimit struct {
+	Uses   int
+	Window time.Duration
+	// RecordLater commands record their uses themselves, e.g. once the work they queued succeeded
+	RecordLater bool
+}
+
+func (r RateLimit) Limited() bool {
+	return r.Uses > 0
+}
+
+// Handler runs a command, the error is shown to the member who issued it.
+type Handler func(ctx *Context) error
+
+// Middleware wraps the handler of a command, e.g. to check permissions before the command runs.
+type Middleware func(next Handler) Handler
+
+// Command is a slash command. A command either has a handler or subcommands, subcommands can have subcommands of their
+// own which Discord shows as a subcommand group.
+type Command struct {
+	Name        string
+	Description string
+	Options     []*discordgo.ApplicationCommandOption
+	Subcommands []*Command
+	Permission  Permission
+	RateLimit   RateLimit
+	// Exclusive commands don't run at the same time as other exclusive commands
+	Exclusive bool
+	Handler   Handler
+}
+
+// ApplicationCommand returns the command as it is registered with Discord.
+func (c *Command) ApplicationCommand() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
+		Name:        c.Name,
+		Description: c.Description,
+		Options:     c.applicationCommandOptions(),
+	}
+}
+
+func (c *Command) applicationCommandOptions() []*discordgo.ApplicationCommandOption {
+	if len(c.Subcommands) == 0 {
+		return c.Options
+	}
+	options := make([]*discordgo.ApplicationCommandOption, len(c.Subcommands))
+	for i, subcommand := range c.Subcommands {
+		optionType := discordgo.ApplicationCommandOptionSubCommand
+		if len(subcommand.Subcommands) > 0 {
+			optionType = discordgo.ApplicationCommandOptionSubCommandGroup
+		}
+		options[i] = &discordgo.ApplicationCommandOption{
+			Type:        optionType,
+			Name:        subcommand.Name,
+			Description: subcommand.Description,
+			Options:     subcommand.applicationCommandOptions(),
+		}
+	}
+	return options
+}
+
+func (c *Command) subcommand(name string) *Command {
+	for _, subcommand := range c.Subcommands {
+		if subcommand.Name == name {
+			return subcommand
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package command
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// Context is a command issued by a member, options are read after the parse options middleware has checked them.
+type Context struct {
+	Session     *discordgo.Session
+	Interaction *discordgo.InteractionCreate
+	// Command is the command or subcommand that runs
+	Command *Command
+	// Name is the full name of the command including its subcommands, e.g. "season close"
+	Name string
+
+	options  []*discordgo.ApplicationCommandInteractionDataOption
+	deferred bool
+}
+
+func (c *Context) GuildID() string {
+	return c.Interaction.GuildID
+}
+
+// UserID returns the ID of the member who issued the command.
+func (c *Context) UserID() string {
+	return c.Interaction.Member.User.ID
+}
+
+// Defer acknowledges the command, the response has to be edited later. Errors of a deferred command are shown by
+// editing the response.
+func (c *Context) Defer(flags discordgo.MessageFlags) error {
+	err := c.Session.InteractionRespond(c.Interaction.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Flags: flags},
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not defer interaction response")
+	}
+	c.deferred = true
+	return nil
+}
+
+// Deferred reports whether the response was deferred.
+func (c *Context) Deferred() bool {
+	return c.deferred
+}
+
+func (c *Context) option(name string) *discordgo.ApplicationCommandInteractionDataOption {
+	for _, option := range c.options {
+		if option.Name == name {
+			return option
+		}
+	}
+	return nil
+}
+
+// String returns the value of a required string option.
+func (c *Context) String(name string) string {
+	value, _ := c.OptionalString(name)
+	return value
+}
+
+func (c *Context) OptionalString(name string) (string, bool) {
+	option := c.option(name)
+	if option == nil || option.Type != discordgo.ApplicationCommandOptionString {
+		return "", false
+	}
+	return option.StringValue(), true
+}
+
+// Int returns the value of a required integer option.
+func (c *Context) Int(name string) int {
+	value, _ := c.OptionalInt(name)
+	return value
+}
+
+func (c *Context) OptionalInt(name string) (int, bool) {
+	option := c.option(name)
+	if option == nil || option.Type != discordgo.ApplicationCommandOptionInteger {
+		return 0, false
+	}
+	return int(option.IntValue()), true
+}
+
+// Bool returns the value of a required boolean option.
+func (c *Context) Bool(name string) bool {
+	value, _ := c.OptionalBool(name)
+	return value
+}
+
+func (c *Context) OptionalBool(name string) (bool, bool) {
+	option := c.option(name)
+	if option == nil || option.Type != discordgo.ApplicationCommandOptionBoolean {
+		return false, false
+	}
+	return option.BoolValue(), true
+}
+
+// User returns the ID of the user of a required user option.
+func (c *Context) User(name string) string {
+	value, _ := c.optionalID(name, discordgo.ApplicationCommandOptionUser)
+	return value
+}
+
+// OptionalChannel returns the ID of the channel of an optional channel option.
+func (c *Context) OptionalChannel(name string) (string, bool) {
+	return c.optionalID(name, discordgo.ApplicationCommandOptionChannel)
+}
+
+// OptionalRole returns the ID of the role of an optional role option.
+func (c *Context) OptionalRole(name string) (string, bool) {
+	return c.optionalID(name, discordgo.ApplicationCommandOptionRole)
+}
+
+// optionalID returns the ID of the user, channel or role of the option, Discord sends their IDs as the value.
+func (c *Context) optionalID(name string, optionType discordgo.ApplicationCommandOptionType) (string, bool) {
+	option := c.option(name)
+	if option == nil || option.Type != optionType {
+		return "", false
+	}
+	id, ok := option.Value.(string)
+	return id, ok
+}
@@ -0,0 +1,113 @@
+package command
+
+import (
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Recover turns a panic of a command into an error, so one broken command doesn't stop the bot.
+func Recover(next Handler) Handler {
+	return func(ctx *Context) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("command /%s panicked: %v\n%s", ctx.Name, r, debug.Stack())
+				err = fmt.Errorf("something went wrong running /%s", ctx.Name)
+			}
+		}()
+		return next(ctx)
+	}
+}
+
+// Log logs every command and the errors of failed commands.
+func Log(next Handler) Handler {
+	return func(ctx *Context) error {
+		log.Printf("/%s by %s in guild %s", ctx.Name, ctx.UserID(), ctx.GuildID())
+		err := next(ctx)
+		if err != nil {
+			log.Printf("/%s failed: %v", ctx.Name, err)
+		}
+		return err
+	}
+}
+
+// ParseOptions checks that the required options of the command are given and that every option has the declared type,
+// so the handler can read its options without checking them again.
+func ParseOptions(next Handler) Handler {
+	return func(ctx *Context) error {
+		declared := map[string]*discordgo.ApplicationCommandOption{}
+		for _, option := range ctx.Command.Options {
+			declared[option.Name] = option
+		}
+		for _, option := range ctx.options {
+			declaredOption, ok := declared[option.Name]
+			if !ok {
+				return fmt.Errorf("unknown option %s", option.Name)
+			}
+			if option.Type != declaredOption.Type {
+				return fmt.Errorf("option %s has to be a %s", option.Name, declaredOption.Type)
+			}
+		}
+		for _, option := range ctx.Command.Options {
+			if option.Required && ctx.option(option.Name) == nil {
+				return fmt.Errorf("option %s not provided", option.Name)
+			}
+		}
+		return next(ctx)
+	}
+}
+
+// RequirePermission only runs commands the member has permission for, isModerator checks whether the member is a
+// moderator.
+func RequirePermission(isModerator func(ctx *Context) bool) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx *Context) error {
+			if ctx.Command.Permission == Moderator && !isModerator(ctx) {
+				return fmt.Errorf("you do not have permission to use /%s", ctx.Name)
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// LimitRate checks the rate limit of the member before limited commands run, allow returns an error if the member
+// has to wait. Only commands that succeed are recorded, so a mistyped option doesn't use up the quota. Commands that
+// record later are only checked.
+func LimitRate(allow, record func(ctx *Context) error) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx *Context) error {
+			if !ctx.Command.RateLimit.Limited() {
+				return next(ctx)
+			}
+			if err := allow(ctx); err != nil {
+				return err
+			}
+			if err := next(ctx); err != nil {
+				return err
+			}
+			if ctx.Command.RateLimit.RecordLater {
+				return nil
+			}
+			if err := record(ctx); err != nil {
+				log.Printf("could not record use of /%s: %v", ctx.Name, err)
+			}
+			return nil
+		}
+	}
+}
+
+// Exclusive runs exclusive commands one at a time.
+func Exclusive(lock *sync.Mutex) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx *Context) error {
+			if ctx.Command.Exclusive {
+				lock.Lock()
+				defer lock.Unlock()
+			}
+			return next(ctx)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Router runs the commands issued by members through the middleware.
+type Router struct {
+	commands   []*Command
+	middleware []Middleware
+	onError    func(ctx *Context, err error)
+}
+
+// NewRouter returns a router that shows errors with onError, the first middleware is the outermost.
+func NewRouter(onError func(ctx *Context, err error), middleware ...Middleware) *Router {
+	return &Router{
+		middleware: middleware,
+		onError:    onError,
+	}
+}
+
+func (r *Router) Add(commands ...*Command) {
+	r.commands = append(r.commands, commands...)
+}
+
+// ApplicationCommands returns the commands as they are registered with Discord.
+func (r *Router) ApplicationCommands() []*discordgo.ApplicationCommand {
+	commands := make([]*discordgo.ApplicationCommand, len(r.commands))
+	for i, command := range r.commands {
+		commands[i] = command.ApplicationCommand()
+	}
+	return commands
+}
+
+// Handle runs the command of the interaction, it can be added as a handler of the Discord session.
+func (r *Router) Handle(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionApplicationCommand {
+		return
+	}
+	data := i.ApplicationCommandData()
+	ctx := &Context{
+		Session:     s,
+		Interaction: i,
+		Name:        data.Name,
+		options:     data.Options,
+	}
+	for _, command := range r.commands {
+		if command.Name == data.Name {
+			ctx.Command = command
+		}
+	}
+	if ctx.Command == nil {
+		return
+	}
+
+	// Discord sends the chosen subcommand as the only option of its parent
+	for len(ctx.Command.Subcommands) > 0 {
+		if len(ctx.options) != 1 {
+			r.onError(ctx, fmt.Errorf("no subcommand of /%s chosen", ctx.Name))
+			return
+		}
+		subcommand := ctx.Command.subcommand(ctx.options[0].Name)
+		if subcommand == nil {
+			r.onError(ctx, fmt.Errorf("unknown subcommand %s of /%s", ctx.options[0].Name, ctx.Name))
+			return
+		}
+		ctx.Name += " " + subcommand.Name
+		ctx.options = ctx.options[0].Options
+		ctx.Command = subcommand
+	}
+
+	if ctx.Command.Handler == nil {
+		r.onError(ctx, ErrNoHandler)
+		return
+	}
+	handler := ctx.Command.Handler
+	for index := len(r.middleware) - 1; index >= 0; index-- {
+		handler = r.middleware[index](handler)
+	}
+	if err := handler(ctx); err != nil {
+		r.onError(ctx, err)
+	}
+}
@@ -0,0 +1,329 @@
+package command_test
+
+import (
+	"sync"
+	"testing"
+	"tmff-discord-app/internal/app/controller/command"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newInteraction(
+	name string,
+	options ...*discordgo.ApplicationCommandInteractionDataOption,
+) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		Type:    discordgo.InteractionApplicationCommand,
+		GuildID: "guild-1",
+		Member:  &discordgo.Member{User: &discordgo.User{ID: "user-1"}},
+		Data: discordgo.ApplicationCommandInteractionData{
+			Name:    name,
+			Options: options,
+		},
+	}}
+}
+
+func option(
+	name string,
+	optionType discordgo.ApplicationCommandOptionType,
+	value interface{},
+) *discordgo.ApplicationCommandInteractionDataOption {
+	return &discordgo.ApplicationCommandInteractionDataOption{Name: name, Type: optionType, Value: value}
+}
+
+// newRouter returns a router with the standard middleware that records the errors of commands.
+func newRouter(errs *[]error, isModerator bool) *command.Router {
+	var lock sync.Mutex
+	return command.NewRouter(
+		func(_ *command.Context, err error) {
+			*errs = append(*errs, err)
+		},
+		command.Recover,
+		command.Log,
+		command.ParseOptions,
+		command.RequirePermission(func(_ *command.Context) bool { return isModerator }),
+		command.LimitRate(
+			func(_ *command.Context) error { return errors.New("wait a bit") },
+			func(_ *command.Context) error { return nil },
+		),
+		command.Exclusive(&lock),
+	)
+}
+
+func TestRouter(t *testing.T) {
+	t.Parallel()
+	t.Run("Test options are parsed", func(t *testing.T) {
+		t.Parallel()
+		var errs []error
+		router := newRouter(&errs, false)
+		var name, user string
+		var games int
+		var allTime, hasAllTime bool
+		router.Add(&command.Command{
+			Name: "test",
+			Options: []*discordgo.ApplicationCommandOption{
+				{Type: discordgo.ApplicationCommandOptionString, Name: "name", Required: true},
+				{Type: discordgo.ApplicationCommandOptionInteger, Name: "games", Required: true},
+				{Type: discordgo.ApplicationCommandOptionUser, Name: "user", Required: true},
+				{Type: discordgo.ApplicationCommandOptionBoolean, Name: "all-time"},
+			},
+			Handler: func(ctx *command.Context) error {
+				name = ctx.String("name")
+				games = ctx.Int("games")
+				user = ctx.User("user")
+				allTime, hasAllTime = ctx.OptionalBool("all-time")
+				return nil
+			},
+		})
+
+		router.Handle(nil, newInteraction(
+			"test",
+			option("name", discordgo.ApplicationCommandOptionString, "Player 1"),
+			// Discord sends numbers as floats
+			option("games", discordgo.ApplicationCommandOptionInteger, float64(3)),
+			option("user", discordgo.ApplicationCommandOptionUser, "user-2"),
+		))
+		require.Empty(t, errs)
+		assert.Equal(t, "Player 1", name)
+		assert.Equal(t, 3, games)
+		assert.Equal(t, "user-2", user)
+		assert.False(t, allTime)
+		assert.False(t, hasAllTime)
+	})
+
+	t.Run("Test missing option", func(t *testing.T) {
+		t.Parallel()
+		var errs []error
+		router := newRouter(&errs, false)
+		router.Add(&command.Command{
+			Name: "test",
+			Options: []*discordgo.ApplicationCommandOption{
+				{Type: discordgo.ApplicationCommandOptionString, Name: "game-link", Required: true},
+				{Type: discordgo.ApplicationCommandOptionString, Name: "name", Required: true},
+			},
+			Handler: func(_ *command.Context) error {
+				t.Error("handler must not run without its required options")
+				return nil
+			},
+		})
+
+		router.Handle(nil, newInteraction("test", option("game-link", discordgo.ApplicationCommandOptionString, "1")))
+		require.Len(t, errs, 1)
+		assert.Equal(t, "option name not provided", errs[0].Error())
+	})
+
+	t.Run("Test option of the wrong type", func(t *testing.T) {
+		t.Parallel()
+		var errs []error
+		router := newRouter(&errs, false)
+		router.Add(&command.Command{
+			Name: "test",
+			Options: []*discordgo.ApplicationCommandOption{
+				{Type: discordgo.ApplicationCommandOptionInteger, Name: "games", Required: true},
+			},
+			Handler: func(_ *command.Context) error { return nil },
+		})
+
+		router.Handle(nil, newInteraction("test", option("games", discordgo.ApplicationCommandOptionString, "3")))
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0].Error(), "option games has to be")
+	})
+
+	t.Run("Test subcommands", func(t *testing.T) {
+		t.Parallel()
+		var errs []error
+		router := newRouter(&errs, true)
+		var ran string
+		router.Add(&command.Command{
+			Name: "season",
+			Subcommands: []*command.Command{
+				{
+					Name: "close",
+					Handler: func(ctx *command.Context) error {
+						ran = ctx.Name
+						return nil
+					},
+				},
+				{
+					Name: "rules",
+					Subcommands: []*command.Command{
+						{
+							Name: "set-min-games",
+							Options: []*discordgo.ApplicationCommandOption{
+								{Type: discordgo.ApplicationCommandOptionInteger, Name: "games", Required: true},
+							},
+							Permission: command.Moderator,
+							Handler: func(ctx *command.Context) error {
+								ran = ctx.Name
+								assert.Equal(t, 5, ctx.Int("games"))
+								return nil
+							},
+						},
+					},
+				},
+			},
+		})
+
+		applicationCommand := router.ApplicationCommands()[0]
+		require.Len(t, applicationCommand.Options, 2)
+		assert.Equal(t, discordgo.ApplicationCommandOptionSubCommand, applicationCommand.Options[0].Type)
+		assert.Equal(t, discordgo.ApplicationCommandOptionSubCommandGroup, applicationCommand.Options[1].Type)
+		assert.Equal(t, "games", applicationCommand.Options[1].Options[0].Options[0].Name)
+
+		router.Handle(nil, newInteraction("season", &discordgo.ApplicationCommandInteractionDataOption{
+			Name: "close",
+			Type: discordgo.ApplicationCommandOptionSubCommand,
+		}))
+		require.Empty(t, errs)
+		assert.Equal(t, "season close", ran)
+
+		router.Handle(nil, newInteraction("season", &discordgo.ApplicationCommandInteractionDataOption{
+			Name: "rules",
+			Type: discordgo.ApplicationCommandOptionSubCommandGroup,
+			Options: []*discordgo.ApplicationCommandInteractionDataOption{{
+				Name: "set-min-games",
+				Type: discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					option("games", discordgo.ApplicationCommandOptionInteger, float64(5)),
+				},
+			}},
+		}))
+		require.Empty(t, errs)
+		assert.Equal(t, "season rules set-min-games", ran)
+	})
+
+	t.Run("Test permission and rate limit", func(t *testing.T) {
+		t.Parallel()
+		var errs []error
+		router := newRouter(&errs, false)
+		router.Add(
+			&command.Command{
+				Name:       "moderators-only",
+				Permission: command.Moderator,
+				Handler:    func(_ *command.Context) error { return nil },
+			},
+			&command.Command{
+				Name:      "limited",
+				RateLimit: command.RateLimit{Uses: 1},
+				Handler:   func(_ *command.Context) error { return nil },
+			},
+			&command.Command{
+				Name:    "unlimited",
+				Handler: func(_ *command.Context) error { return nil },
+			},
+		)
+
+		router.Handle(nil, newInteraction("moderators-only"))
+		router.Handle(nil, newInteraction("limited"))
+		router.Handle(nil, newInteraction("unlimited"))
+		require.Len(t, errs, 2)
+		assert.Equal(t, "you do not have permission to use /moderators-only", errs[0].Error())
+		assert.Equal(t, "wait a bit", errs[1].Error())
+	})
+
+	t.Run("Test only successful commands are recorded", func(t *testing.T) {
+		t.Parallel()
+		var errs []error
+		var recorded []string
+		router := command.NewRouter(
+			func(_ *command.Context, err error) {
+				errs = append(errs, err)
+			},
+			command.ParseOptions,
+			command.LimitRate(
+				func(_ *command.Context) error {
+					if len(recorded) > 0 {
+						return errors.New("wait a bit")
+					}
+					return nil
+				},
+				func(ctx *command.Context) error {
+					recorded = append(recorded, ctx.String("game-link"))
+					return nil
+				},
+			),
+		)
+		router.Add(&command.Command{
+			Name: "register-game",
+			Options: []*discordgo.ApplicationCommandOption{
+				{Type: discordgo.ApplicationCommandOptionString, Name: "game-link", Required: true},
+			},
+			RateLimit: command.RateLimit{Uses: 1},
+			Handler: func(ctx *command.Context) error {
+				if ctx.String("game-link") == "mistyped" {
+					return errors.New("invalid game link")
+				}
+				return nil
+			},
+		})
+
+		router.Handle(nil, newInteraction(
+			"register-game",
+			option("game-link", discordgo.ApplicationCommandOptionString, "mistyped"),
+		))
+		router.Handle(nil, newInteraction(
+			"register-game",
+			option("game-link", discordgo.ApplicationCommandOptionString, "571581855"),
+		))
+		router.Handle(nil, newInteraction(
+			"register-game",
+			option("game-link", discordgo.ApplicationCommandOptionString, "571581856"),
+		))
+		assert.Equal(t, []string{"571581855"}, recorded)
+		require.Len(t, errs, 2)
+		assert.Equal(t, "invalid game link", errs[0].Error())
+		assert.Equal(t, "wait a bit", errs[1].Error())
+	})
+
+	t.Run("Test commands that record later are only checked", func(t *testing.T) {
+		t.Parallel()
+		var errs []error
+		allowed, recorded := 0, 0
+		router := command.NewRouter(
+			func(_ *command.Context, err error) {
+				errs = append(errs, err)
+			},
+			command.LimitRate(
+				func(_ *command.Context) error {
+					allowed++
+					return nil
+				},
+				func(_ *command.Context) error {
+					recorded++
+					return nil
+				},
+			),
+		)
+		router.Add(&command.Command{
+			Name:      "register-game",
+			RateLimit: command.RateLimit{Uses: 1, RecordLater: true},
+			Handler:   func(_ *command.Context) error { return nil },
+		})
+
+		router.Handle(nil, newInteraction("register-game"))
+		assert.Empty(t, errs)
+		assert.Equal(t, 1, allowed)
+		assert.Equal(t, 0, recorded)
+	})
+
+	t.Run("Test panic is recovered", func(t *testing.T) {
+		t.Parallel()
+		var errs []error
+		router := newRouter(&errs, false)
+		router.Add(&command.Command{
+			Name: "broken",
+			Handler: func(_ *command.Context) error {
+				var players []string
+				_ = players[1]
+				return nil
+			},
+		})
+
+		router.Handle(nil, newInteraction("broken"))
+		require.Len(t, errs, 1)
+		assert.Equal(t, "something went wrong running /broken", errs[0].Error())
+	})
+}
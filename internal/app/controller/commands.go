@@ -0,0 +1,534 @@
+package controller
+
+import (
+	"tmff-discord-app/internal/app/controller/command"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+//nolint:gochecknoglobals // Discord needs a pointer to the minimum option value.
+var minGamesMinValue = 0.0
+
+//nolint:gochecknoglobals // Discord needs a pointer to the minimum option value.
+var rateLimitMinValue = 0.0
+
+//nolint:gochecknoglobals // Discord needs a pointer to the minimum option value.
+var jobMinValue = 1.0
+
+// registerGameRateLimit is recorded by the registration worker once the game is rated, so games that fail to register
+// don't use up the quota.
+//
+//nolint:gochecknoglobals // Shared by the command and the registration worker.
+var registerGameRateLimit = command.RateLimit{Uses: 3, RecordLater: true}
+
+// Router returns the router of the slash commands, the middleware checks the permission and the rate limit of the
+// member before a command runs.
+func (g *FanFaction) Router() *command.Router {
+	router := command.NewRouter(
+		g.respondWithCommandError,
+		command.Recover,
+		command.Log,
+		command.ParseOptions,
+		command.RequirePermission(func(ctx *command.Context) bool {
+			return g.isModerator(ctx.Session, ctx.Interaction)
+		}),
+		command.LimitRate(g.allowCommand, g.recordCommand),
+		command.Exclusive(&g.commandLock),
+	)
+	router.Add(g.commands()...)
+	return router
+}
+
+func (g *FanFaction) commands() []*command.Command {
+	return []*command.Command{
+		{
+			Name:        "register-game",
+			Description: "There has to be at least two registered participants in the game.",
+			RateLimit:   registerGameRateLimit,
+			Handler:     g.RegisterGame,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "game-link",
+					Description: "The link to the game on Board Game Arena, e.g. https://boardgamearena.com/table?table=571581855",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "queue",
+			Description: "The games waiting to be registered.",
+			Subcommands: []*command.Command{
+				{
+					Name:        "show",
+					Description: "Show the pending and failed games.",
+					Handler:     g.ShowQueue,
+				},
+				{
+					Name:        "retry",
+					Description: "Queue a failed game again.",
+					Permission:  command.Moderator,
+					Handler:     g.RetryRegistrationJob,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "job",
+							Description: "The number of the failed game in /queue show.",
+							Required:    true,
+							MinValue:    &jobMinValue,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "add-player",
+			Description: "Add a player.",
+			Permission:  command.Moderator,
+			Handler:     g.AddPlayer,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "name",
+					Description: "The username of the player.",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "id",
+					Description: "The BGA ID of the player.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "register-me",
+			Description: "Register yourself as a player, a moderator has to approve the registration.",
+			RateLimit:   command.RateLimit{Uses: 1},
+			Handler:     g.RegisterMe,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "bga-name",
+					Description: "Your username on Board Game Arena.",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "bga-id",
+					Description: "Your BGA ID, the number at the end of your profile link.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "link",
+			Description: "Link your Discord account to a player, a moderator has to approve the link.",
+			Exclusive:   true,
+			Handler:     g.Link,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "bga-name",
+					Description: "Your username on Board Game Arena.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "approve-link",
+			Description: "Approve the pending link of a Discord account to a player.",
+			Permission:  command.Moderator,
+			Exclusive:   true,
+			Handler:     g.ApproveLink,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "The Discord user that requested the link.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "rename-player",
+			Description: "Rename a player, e.g. to fix a typo or after a name change on Board Game Arena.",
+			Permission:  command.Moderator,
+			Exclusive:   true,
+			Handler:     g.RenamePlayer,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "name",
+					Description: "The current name of the player.",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "new-name",
+					Description: "The new name of the player.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "set-bga-id",
+			Description: "Change the BGA ID of a player.",
+			Permission:  command.Moderator,
+			Exclusive:   true,
+			Handler:     g.SetBGAID,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "name",
+					Description: "The name of the player.",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "id",
+					Description: "The new BGA ID of the player.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "merge-players",
+			Description: "Merge two players of the same person, the ratings of the affected seasons are recalculated.",
+			Permission:  command.Moderator,
+			Exclusive:   true,
+			Handler:     g.MergePlayers,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "source",
+					Description: "The player that is merged and removed.",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "target",
+					Description: "The player that is kept.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "void-game",
+			Description: "Void a game that broke the league rules, the games registered after it are rated again.",
+			Permission:  command.Moderator,
+			Exclusive:   true,
+			Handler:     g.VoidGame,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "game-link",
+					Description: "The link to the game on Board Game Arena.",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "reason",
+					Description: "Why the game is voided, the reason is posted in the games channel.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "add-account",
+			Description: "Add an additional BGA account to a player, games of the account are rated for the player.",
+			Permission:  command.Moderator,
+			Handler:     g.AddAccount,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "player",
+					Description: "The name of the player.",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "bga-name",
+					Description: "The username of the additional account.",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "bga-id",
+					Description: "The BGA ID of the additional account.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "remove-account",
+			Description: "Remove an additional BGA account from a player.",
+			Permission:  command.Moderator,
+			Exclusive:   true,
+			Handler:     g.RemoveAccount,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "bga-name",
+					Description: "The username of the additional account.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "deactivate-player",
+			Description: "Retire a player, their games are no longer rated.",
+			Permission:  command.Moderator,
+			Exclusive:   true,
+			Handler:     g.DeactivatePlayer,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "name",
+					Description: "The name of the player.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "anonymize-player",
+			Description: "Forget a player, their games are kept under a pseudonym.",
+			Permission:  command.Moderator,
+			Exclusive:   true,
+			Handler:     g.AnonymizePlayer,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "name",
+					Description: "The name of the player.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "set-min-games",
+			Description: "Set the minimum number of games to qualify for the final standings of the current season.",
+			Permission:  command.Moderator,
+			Exclusive:   true,
+			Handler:     g.SetMinGames,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "games",
+					Description: "The minimum number of games.",
+					Required:    true,
+					MinValue:    &minGamesMinValue,
+				},
+			},
+		},
+		{
+			Name:        "leaderboard",
+			Description: "Show the leaderboard of the current season.",
+			Handler:     g.ShowLeaderboard,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "all-time",
+					Description: "Show the all-time leaderboard across every season instead.",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "export",
+					Description: "Attach the all-time leaderboard as a CSV file.",
+				},
+			},
+		},
+		{
+			Name:        "profile",
+			Description: "Show the statistics of a player in the current season.",
+			Handler:     g.ShowProfile,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "player",
+					Description: "The name of the player, defaults to your linked player.",
+				},
+			},
+		},
+		{
+			Name:        "elo-chart",
+			Description: "Show a chart of the Elo of players in the current season.",
+			Handler:     g.ShowEloChart,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "player",
+					Description: "The name of the player, defaults to your linked player.",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "compare-with",
+					Description: "Another player to show in the chart.",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "compare-with-2",
+					Description: "Another player to show in the chart.",
+				},
+			},
+		},
+		{
+			Name:        "h2h",
+			Description: "Show the head to head record of two players.",
+			Handler:     g.ShowHeadToHead,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "player-a",
+					Description: "The name of the first player.",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "player-b",
+					Description: "The name of the second player.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "join-season",
+			Description: "Sign up for the current season, moderators can sign up another player.",
+			Exclusive:   true,
+			Handler:     g.JoinSeason,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "player",
+					Description: "The name of the player to sign up, moderators only.",
+				},
+			},
+		},
+		{
+			Name:        "require-sign-up",
+			Description: "Only rate games of players that have joined the current season.",
+			Permission:  command.Moderator,
+			Exclusive:   true,
+			Handler:     g.RequireSignUp,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "required",
+					Description: "Whether players have to join the season before their games are rated.",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "create-season",
+			Description: "Create a new season, rules that are not given are copied from the current season.",
+			Permission:  command.Moderator,
+			Exclusive:   true,
+			Handler:     g.CreateSeason,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "name",
+					Description: "The name of the season.",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "k-factor",
+					Description: "The K factor used in the Elo calculation.",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "start-elo",
+					Description: "The Elo a player starts the season with.",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "elo-floor",
+					Description: "The lowest Elo a player can drop to.",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "min-players",
+					Description: "The minimum number of registered players in a game.",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "max-game-age-days",
+					Description: "The maximum age of a game when it is registered.",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "fan-factions",
+					Description: "The fan faction settings games can be played with.",
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "On - with Fire & Ice", Value: "with-fire-and-ice"},
+						{Name: "On - no Fire & Ice", Value: "no-fire-and-ice"},
+						{Name: "Both", Value: "both"},
+					},
+				},
+			},
+		},
+		{
+			Name:        "close-season",
+			Description: "Close the current season and post the season report.",
+			Permission:  command.Moderator,
+			Exclusive:   true,
+			Handler:     g.CloseSeason,
+		},
+		{
+			Name:        "config",
+			Description: "Show the settings of the server, or change them with the options.",
+			Permission:  command.Moderator,
+			Exclusive:   true,
+			Handler:     g.Config,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "games-channel",
+					Description:  "The channel game results are posted to.",
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "leaderboard-channel",
+					Description:  "The channel the leaderboard is posted to.",
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "registered-players-channel",
+					Description:  "The channel the registered players are posted to.",
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "staff-channel",
+					Description:  "The channel registration requests are posted to.",
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionRole,
+					Name:        "add-moderator-role",
+					Description: "Allow a role to use the moderator commands.",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionRole,
+					Name:        "remove-moderator-role",
+					Description: "No longer allow a role to use the moderator commands.",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "rate-limit-minutes",
+					Description: "The window the uses of rate limited commands by members without a moderator role count in.",
+					MinValue:    &rateLimitMinValue,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "public-results",
+					Description: "Post game results to the games channel instead of only to the member who registered it.",
+				},
+			},
+		},
+	}
+}
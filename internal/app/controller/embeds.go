@@ -0,0 +1,230 @@
+package controller
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	repomodel "tmff-discord-app/internal/app/repository/model"
+	"tmff-discord-app/internal/app/services/model"
+	"tmff-discord-app/pkg/codeblock"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Embed colors.
+const (
+	colorGameResult  = 0x2ECC71
+	colorLeaderboard = 0xF1C40F
+	colorPlayers     = 0x3498DB
+)
+
+// maxEmbedDescriptionLength is the most characters Discord shows in the description of an embed.
+const maxEmbedDescriptionLength = 4096
+
+// podiumSize is the number of players that are highlighted at the top of a leaderboard.
+const podiumSize = 3
+
+//nolint:gochecknoglobals // Medals of the podium by position.
+var medals = []string{"🥇", "🥈", "🥉"}
+
+//nolint:gochecknoglobals // Characters Discord reads as markdown.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, `*`, `\*`, `_`, `\_`, `~`, `\~`, "`", "\\`", `|`, `\|`, `>`, `\>`, `[`, `\[`, `]`, `\]`,
+)
+
+// richMessage is a message with embeds, text is sent instead in channels the bot can't embed links in.
+type richMessage struct {
+	content string
+	embeds  []*discordgo.MessageEmbed
+	text    string
+}
+
+func textMessage(text string) *richMessage {
+	return &richMessage{text: text}
+}
+
+func (m *richMessage) messageSend(embedLinks bool) *discordgo.MessageSend {
+	if embedLinks && len(m.embeds) > 0 {
+		return &discordgo.MessageSend{Content: m.content, Embeds: m.embeds}
+	}
+	return &discordgo.MessageSend{Content: m.text}
+}
+
+// canEmbedLinks checks that the bot may send embeds in the channel, if the permissions are not known yet it assumes
+// the bot can.
+func canEmbedLinks(s *discordgo.Session, channelID string) bool {
+	permissions, err := s.State.UserChannelPermissions(s.State.User.ID, channelID)
+	if err != nil {
+		log.Printf("could not get permissions in channel %s: %v", channelID, err)
+		return true
+	}
+	return permissions&discordgo.PermissionEmbedLinks != 0
+}
+
+func formatGameResultMessage(
+	i *discordgo.InteractionCreate,
+	gameResult []*model.PlayerEloResult,
+	gameOutcome *model.GameOutcome,
+) *richMessage {
+	fields := make([]*discordgo.MessageEmbedField, len(gameResult))
+	for position, result := range gameResult {
+		fields[position] = &discordgo.MessageEmbedField{
+			Name: fmt.Sprintf("%s %s", ordinal(position+1), escapeMarkdown(result.Name)),
+			Value: fmt.Sprintf(
+				"%d VP\n%s\n%d Elo",
+				result.Score,
+				formatEloChange(result.EloChange),
+				result.EloBefore+result.EloChange,
+			),
+			Inline: true,
+		}
+	}
+	embed := &discordgo.MessageEmbed{
+		Title:       "Game " + gameOutcome.ID,
+		URL:         gameOutcome.BGALink(),
+		Description: "Fan factions: " + gameOutcome.FanFactionSetting.String(),
+		Color:       colorGameResult,
+		Fields:      fields,
+	}
+	if gameOutcome.CreationTime != nil {
+		embed.Timestamp = gameOutcome.CreationTime.Format(time.RFC3339)
+	}
+
+	content := fmt.Sprintf("Thank you for registering a game <@%s>!", i.Member.User.ID)
+	if mentions := formatMentions(gameResult); mentions != "" {
+		content += fmt.Sprintf("\nWell played %s!", mentions)
+	}
+	return &richMessage{
+		content: content,
+		embeds:  []*discordgo.MessageEmbed{embed},
+		text:    formatGameResult(i, gameResult, gameOutcome.BGALink()),
+	}
+}
+
+func formatEloChange(eloChange int) string {
+	switch {
+	case eloChange > 0:
+		return fmt.Sprintf("🟢 ▲ %+d", eloChange)
+	case eloChange < 0:
+		return fmt.Sprintf("🔴 ▼ %d", eloChange)
+	default:
+		return "⚪ ±0"
+	}
+}
+
+func formatMentions(gameResult []*model.PlayerEloResult) string {
+	var mentions []string
+	for _, result := range gameResult {
+		if result.DiscordID != "" {
+			mentions = append(mentions, fmt.Sprintf("<@%s>", result.DiscordID))
+		}
+	}
+	return strings.Join(mentions, " ")
+}
+
+// formatLeaderboardEmbeds returns the pages of the leaderboard, the podium is highlighted on the first page.
+func formatLeaderboardEmbeds(leaderboard *model.Leaderboard) []*discordgo.MessageEmbed {
+	var podium []*discordgo.MessageEmbedField
+	var lines []string
+	for index, entry := range leaderboard.Entries {
+		if index < podiumSize {
+			podium = append(podium, &discordgo.MessageEmbedField{
+				Name:   fmt.Sprintf("%s %s", medals[index], escapeMarkdown(entry.PlayerName)),
+				Value:  fmt.Sprintf("%d Elo\n%d games", entry.Elo, entry.GamesPlayed),
+				Inline: true,
+			})
+			continue
+		}
+		lines = append(lines, fmt.Sprintf(
+			"`%3d` %s · %d Elo · %d games",
+			index+1,
+			escapeMarkdown(entry.PlayerName),
+			entry.Elo,
+			entry.GamesPlayed,
+		))
+	}
+	if len(leaderboard.Unqualified) > 0 {
+		lines = append(lines, "", fmt.Sprintf("**Unqualified** (minimum %d games)", leaderboard.MinGames))
+		for _, entry := range leaderboard.Unqualified {
+			lines = append(lines, fmt.Sprintf(
+				"`  -` %s · %d Elo · %d more games",
+				escapeMarkdown(entry.PlayerName),
+				entry.Elo,
+				entry.GamesNeeded,
+			))
+		}
+	}
+	return formatEmbedPages("Leaderboard", colorLeaderboard, podium, lines)
+}
+
+func formatAllTimeLeaderboardEmbeds(leaderboard *model.AllTimeLeaderboard) []*discordgo.MessageEmbed {
+	var podium []*discordgo.MessageEmbedField
+	var lines []string
+	for index, entry := range leaderboard.Entries {
+		if index < podiumSize {
+			podium = append(podium, &discordgo.MessageEmbedField{
+				Name: fmt.Sprintf("%s %s", medals[index], escapeMarkdown(entry.PlayerName)),
+				Value: fmt.Sprintf(
+					"%d Elo\n%d games, %d wins\n%.2f avg position",
+					entry.Elo,
+					entry.GamesPlayed,
+					entry.Wins,
+					entry.AveragePosition,
+				),
+				Inline: true,
+			})
+			continue
+		}
+		lines = append(lines, fmt.Sprintf(
+			"`%3d` %s · %d Elo · %d games · %d wins · %.2f avg position",
+			index+1,
+			escapeMarkdown(entry.PlayerName),
+			entry.Elo,
+			entry.GamesPlayed,
+			entry.Wins,
+			entry.AveragePosition,
+		))
+	}
+	return formatEmbedPages("All-time Leaderboard", colorLeaderboard, podium, lines)
+}
+
+func formatPlayersEmbeds(players []*repomodel.Player) []*discordgo.MessageEmbed {
+	lines := make([]string, len(players))
+	for index, player := range players {
+		lines[index] = fmt.Sprintf(
+			"[%s](https://boardgamearena.com/player?id=%s) · %s",
+			escapeMarkdown(player.Name),
+			player.BGAID,
+			player.BGAID,
+		)
+	}
+	return formatEmbedPages("Players", colorPlayers, nil, lines)
+}
+
+// formatEmbedPages spreads the lines over as many embeds as needed, the fields are shown on the first page.
+func formatEmbedPages(
+	title string,
+	color int,
+	fields []*discordgo.MessageEmbedField,
+	lines []string,
+) []*discordgo.MessageEmbed {
+	pages := codeblock.Paginate(lines, maxEmbedDescriptionLength)
+	embeds := make([]*discordgo.MessageEmbed, len(pages))
+	for index, page := range pages {
+		embeds[index] = &discordgo.MessageEmbed{
+			Title:       title,
+			Color:       color,
+			Description: strings.Join(page, "\n"),
+		}
+		if len(pages) > 1 {
+			embeds[index].Title = fmt.Sprintf("%s (%d/%d)", title, index+1, len(pages))
+		}
+	}
+	embeds[0].Fields = fields
+	return embeds
+}
+
+func escapeMarkdown(text string) string {
+	return markdownEscaper.Replace(text)
+}
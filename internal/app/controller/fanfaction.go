@@ -1,214 +1,1197 @@
 package controller
 
 import (
+	"bytes"
 	"fmt"
 	"log"
 	"net/url"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
-	"tmff-discord-app/internal/app/config"
-	"tmff-discord-app/internal/app/repository"
+	"tmff-discord-app/internal/app/controller/command"
 	repomodel "tmff-discord-app/internal/app/repository/model"
 	"tmff-discord-app/internal/app/services"
 	"tmff-discord-app/internal/app/services/model"
+	"tmff-discord-app/pkg/codeblock"
+	"tmff-discord-app/pkg/retry"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/pkg/errors"
 )
 
+// Fan faction settings that can be allowed in a season.
+//
+//nolint:gochecknoglobals // Map from command choice to settings.
+var fanFactionChoices = map[string][]model.FanFactionSetting{
+	"with-fire-and-ice": {model.On},
+	"no-fire-and-ice":   {model.OnNoFireAndIce},
+	"both":              {model.On, model.OnNoFireAndIce},
+}
+
+// Custom ID prefixes of the registration review buttons.
+const (
+	approveRegistrationID = "approve-registration"
+	rejectRegistrationID  = "reject-registration"
+)
+
 type FanFaction struct {
-	gameService        *services.Game
-	playerRepo         *repository.Player
-	leaderboardService *services.Leaderboard
-	gameScraper        *services.GameScraper
-	conf               *config.Config
-	commandLock        sync.Mutex
-	lastCommandByUser  map[string]time.Time
+	leagues           *services.Leagues
+	guildSettings     *services.GuildSettings
+	managedMessages   *services.ManagedMessages
+	gameScraper       *services.GameScraper
+	profileScraper    *services.ProfileScraper
+	rateLimiter       *services.RateLimiter
+	registrationQueue *services.RegistrationQueue
+	// jobQueued wakes the registration worker up
+	jobQueued   chan struct{}
+	commandLock sync.Mutex
 }
 
 func NewFanFaction(
-	conf *config.Config,
-	playerRepo *repository.Player,
-	gameService *services.Game,
-	leaderboardService *services.Leaderboard,
+	leagues *services.Leagues,
+	guildSettings *services.GuildSettings,
+	managedMessages *services.ManagedMessages,
 	gameScraper *services.GameScraper,
+	profileScraper *services.ProfileScraper,
+	rateLimiter *services.RateLimiter,
+	registrationQueue *services.RegistrationQueue,
 ) *FanFaction {
 	return &FanFaction{
-		gameService:        gameService,
-		leaderboardService: leaderboardService,
-		gameScraper:        gameScraper,
-		conf:               conf,
-		playerRepo:         playerRepo,
-		lastCommandByUser:  map[string]time.Time{},
+		leagues:           leagues,
+		guildSettings:     guildSettings,
+		managedMessages:   managedMessages,
+		gameScraper:       gameScraper,
+		profileScraper:    profileScraper,
+		rateLimiter:       rateLimiter,
+		registrationQueue: registrationQueue,
+		jobQueued:         make(chan struct{}, 1),
 	}
 }
 
-func (g *FanFaction) FanFactionCommands() (
-	[]*discordgo.ApplicationCommand,
-	map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate),
-) {
-	commands := []*discordgo.ApplicationCommand{
-		{
-			Name:        "register-game",
-			Description: "There has to be at least two registered participants in the game.",
-			Options: []*discordgo.ApplicationCommandOption{
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "game-link",
-					Description: "The link to the game on Board Game Arena, e.g. https://boardgamearena.com/table?table=571581855",
-					Required:    true,
+// FanFactionComponents returns the handlers of the message components, keyed by the prefix of their custom ID.
+func (g *FanFaction) FanFactionComponents() map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	return map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate){
+		approveRegistrationID: g.ApproveRegistration,
+		rejectRegistrationID:  g.RejectRegistration,
+	}
+}
+
+func (g *FanFaction) RegisterGame(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
+
+	settings, err := g.guildSettings.Get(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	// The response shows the progress and is replaced by the result once the game is registered
+	var flags discordgo.MessageFlags
+	if !settings.PublicResults {
+		flags = discordgo.MessageFlagsEphemeral
+	}
+	err = ctx.Defer(flags)
+	if err != nil {
+		return err
+	}
+
+	// The worker reports the progress and the result in the response, and records the use once the game is rated
+	job := &model.RegistrationJob{
+		GuildID:          i.GuildID,
+		GameLink:         ctx.String("game-link"),
+		UserID:           i.Member.User.ID,
+		ChannelID:        i.ChannelID,
+		AppID:            i.AppID,
+		InteractionToken: i.Token,
+	}
+	err = g.registrationQueue.Enqueue(job)
+	if err != nil {
+		return err
+	}
+	position, err := g.registrationQueue.Position(job)
+	if err != nil {
+		log.Printf("could not get position of registration job %d: %v", job.ID, err)
+	}
+	g.editResponse(s, i, fmt.Sprintf("<@%s> the game is queued (position %d)", i.Member.User.ID, position+1))
+	g.wakeRegistrationWorker()
+	return nil
+}
+
+func (g *FanFaction) AddPlayer(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
+
+	league, err := g.leagues.Get(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	playerName := ctx.String("name")
+	playerID := ctx.String("id")
+
+	// Loading the BGA profile can take longer than Discord waits for a response
+	err = ctx.Defer(0)
+	if err != nil {
+		return err
+	}
+
+	err = g.profileScraper.VerifyProfile(playerName, playerID)
+	if err != nil {
+		return err
+	}
+
+	// The profile is verified without the lock, so a slow BGA page doesn't hold up the other commands
+	g.commandLock.Lock()
+	err = league.PlayerRepo.InsertPlayer(playerName, playerID)
+	g.commandLock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	g.editResponse(s, i, fmt.Sprintf("<@%s> added player %s with ID %s", i.Member.User.ID, playerName, playerID))
+
+	g.updateRegisteredPlayers(s, i.GuildID)
+	return nil
+}
+
+func (g *FanFaction) RegisterMe(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
+
+	league, err := g.leagues.Get(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	playerName := ctx.String("bga-name")
+	bgaID := ctx.String("bga-id")
+
+	staffChannelID, err := g.getChannelID(s, i.GuildID, model.StaffChannel)
+	if err != nil {
+		return err
+	}
+
+	// Loading the BGA profile can take longer than Discord waits for a response
+	err = ctx.Defer(0)
+	if err != nil {
+		return err
+	}
+
+	err = g.profileScraper.VerifyProfile(playerName, bgaID)
+	if err != nil {
+		return err
+	}
+
+	// The profile is verified without the lock, so a slow BGA page doesn't hold up the other commands
+	g.commandLock.Lock()
+	request, err := league.Players.RequestRegistration(playerName, bgaID, i.Member.User.ID)
+	g.commandLock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.ChannelMessageSendComplex(staffChannelID, &discordgo.MessageSend{
+		Content: fmt.Sprintf(
+			"<@%s> wants to register as %s with ID %s https://boardgamearena.com/player?id=%s",
+			request.DiscordID,
+			request.Name,
+			request.BGAID,
+			request.BGAID,
+		),
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    "Approve",
+						Style:    discordgo.SuccessButton,
+						CustomID: fmt.Sprintf("%s:%d", approveRegistrationID, request.ID),
+					},
+					discordgo.Button{
+						Label:    "Reject",
+						Style:    discordgo.DangerButton,
+						CustomID: fmt.Sprintf("%s:%d", rejectRegistrationID, request.ID),
+					},
 				},
 			},
 		},
-		{
-			Name:        "add-player",
-			Description: "Add a player.",
-			Options: []*discordgo.ApplicationCommandOption{
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "name",
-					Description: "The username of the player.",
-					Required:    true,
-				},
+	})
+	if err != nil {
+		// Without the review message no moderator can approve the request, so it is removed to allow another try
+		g.commandLock.Lock()
+		cancelErr := league.Players.CancelRegistration(request.ID)
+		g.commandLock.Unlock()
+		if cancelErr != nil {
+			log.Printf("could not cancel registration request %d: %v", request.ID, cancelErr)
+		}
+		return errors.Wrap(err, "could not send your registration to the moderators, please try again later")
+	}
+
+	g.editResponse(s, i, fmt.Sprintf(
+		"<@%s> your registration as %s is waiting for a moderator to approve it",
+		i.Member.User.ID,
+		request.Name,
+	))
+	return nil
+}
+
+func (g *FanFaction) ApproveRegistration(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	g.commandLock.Lock()
+	defer g.commandLock.Unlock()
+	log.Println("approving registration")
+
+	league, leagueErr := g.leagues.Get(i.GuildID)
+	if leagueErr != nil {
+		g.respondWithError(s, i, leagueErr)
+		return
+	}
+
+	if !g.isModerator(s, i) {
+		err := errors.New("you do not have permission to approve a registration")
+		g.respondWithError(s, i, err)
+		return
+	}
+
+	requestID, err := getComponentArgument(i)
+	if err != nil {
+		g.respondWithError(s, i, err)
+		return
+	}
+
+	player, err := league.Players.ApproveRegistration(requestID, i.Member.User.ID)
+	if err != nil {
+		g.respondWithError(s, i, err)
+		return
+	}
+
+	g.closeReviewMessage(s, i, fmt.Sprintf(
+		"<@%s> approved the registration of player %s by <@%s>",
+		i.Member.User.ID,
+		player.Name,
+		*player.DiscordID,
+	))
+	g.updateRegisteredPlayers(s, i.GuildID)
+}
+
+func (g *FanFaction) RejectRegistration(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	g.commandLock.Lock()
+	defer g.commandLock.Unlock()
+	log.Println("rejecting registration")
+
+	league, leagueErr := g.leagues.Get(i.GuildID)
+	if leagueErr != nil {
+		g.respondWithError(s, i, leagueErr)
+		return
+	}
+
+	if !g.isModerator(s, i) {
+		err := errors.New("you do not have permission to reject a registration")
+		g.respondWithError(s, i, err)
+		return
+	}
+
+	requestID, err := getComponentArgument(i)
+	if err != nil {
+		g.respondWithError(s, i, err)
+		return
+	}
+
+	request, err := league.Players.RejectRegistration(requestID, i.Member.User.ID)
+	if err != nil {
+		g.respondWithError(s, i, err)
+		return
+	}
+
+	g.closeReviewMessage(s, i, fmt.Sprintf(
+		"<@%s> rejected the registration of player %s by <@%s>",
+		i.Member.User.ID,
+		request.Name,
+		request.DiscordID,
+	))
+}
+
+// closeReviewMessage replaces the review message with the outcome and removes its buttons.
+func (g *FanFaction) closeReviewMessage(s *discordgo.Session, i *discordgo.InteractionCreate, content string) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    content,
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+	if err != nil {
+		log.Printf("could not respond to interaction: %v", err)
+	}
+}
+
+func (g *FanFaction) updateRegisteredPlayers(s *discordgo.Session, guildID string) {
+	league, err := g.leagues.Get(guildID)
+	if err != nil {
+		log.Printf("could not get league: %v", err)
+		return
+	}
+	players, err := league.PlayerRepo.GetActivePlayers()
+	if err != nil {
+		log.Printf("could not get players: %v", err)
+		return
+	}
+
+	sort.Slice(players, func(i, j int) bool {
+		return strings.ToLower(players[i].Name) < strings.ToLower(players[j].Name)
+	})
+
+	registeredPlayersChannelID, getChannelErr := g.getChannelID(s, guildID, model.RegisteredPlayersChannel)
+	if getChannelErr != nil {
+		log.Printf("could not get registered players channel ID: %v", getChannelErr)
+		return
+	}
+
+	pages := managedPages(s, registeredPlayersChannelID, formatPlayersEmbeds(players), formatPlayers(players))
+	err = g.updatePages(s, guildID, registeredPlayersChannelID, services.PlayersMessage, "Players", pages)
+	if err != nil {
+		log.Printf("could not update players: %v", err)
+	}
+}
+
+func (g *FanFaction) Link(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
+
+	league, err := g.leagues.Get(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	playerName := ctx.String("bga-name")
+
+	player, err := league.Players.RequestLink(playerName, i.Member.User.ID)
+	if err != nil {
+		return err
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf(
+				"<@%s> requested a link to player %s, a moderator has to approve it with /approve-link",
+				i.Member.User.ID,
+				player.Name,
+			),
+		},
+	})
+	if err != nil {
+		log.Printf("could not respond to interaction: %v", err)
+	}
+	return nil
+}
+
+func (g *FanFaction) ApproveLink(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
+
+	league, err := g.leagues.Get(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	discordID := ctx.User("user")
+
+	player, err := league.Players.ApproveLink(discordID)
+	if err != nil {
+		return err
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("<@%s> linked <@%s> to player %s", i.Member.User.ID, discordID, player.Name),
+		},
+	})
+	if err != nil {
+		log.Printf("could not respond to interaction: %v", err)
+	}
+	return nil
+}
+
+func (g *FanFaction) RenamePlayer(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
+
+	league, err := g.leagues.Get(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	playerName := ctx.String("name")
+	newName := ctx.String("new-name")
+
+	err = league.Players.RenamePlayer(playerName, newName)
+	if err != nil {
+		return err
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("<@%s> renamed player %s to %s", i.Member.User.ID, playerName, newName),
+		},
+	})
+	if err != nil {
+		log.Printf("could not respond to interaction: %v", err)
+	}
+
+	g.updateRegisteredPlayers(s, i.GuildID)
+	err = g.UpdateLeaderboard(s, i.GuildID)
+	if err != nil {
+		log.Printf("could not update leaderboard: %v", err)
+	}
+	return nil
+}
+
+func (g *FanFaction) SetBGAID(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
+
+	league, err := g.leagues.Get(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	playerName := ctx.String("name")
+	bgaID := ctx.String("id")
+
+	err = league.Players.SetBGAID(playerName, bgaID)
+	if err != nil {
+		return err
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("<@%s> changed the ID of player %s to %s", i.Member.User.ID, playerName, bgaID),
+		},
+	})
+	if err != nil {
+		log.Printf("could not respond to interaction: %v", err)
+	}
+
+	g.updateRegisteredPlayers(s, i.GuildID)
+	return nil
+}
+
+func (g *FanFaction) MergePlayers(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
+
+	league, err := g.leagues.Get(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	sourceName := ctx.String("source")
+	targetName := ctx.String("target")
+
+	err = league.Players.MergePlayers(sourceName, targetName)
+	if err != nil {
+		return err
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("<@%s> merged player %s into %s", i.Member.User.ID, sourceName, targetName),
+		},
+	})
+	if err != nil {
+		log.Printf("could not respond to interaction: %v", err)
+	}
+
+	g.updateRegisteredPlayers(s, i.GuildID)
+	err = g.UpdateLeaderboard(s, i.GuildID)
+	if err != nil {
+		log.Printf("could not update leaderboard: %v", err)
+	}
+	return nil
+}
+
+// VoidGame voids a game that broke the rules of the league. The game is announced as void in the games channel since
+// the ratings of its players change.
+func (g *FanFaction) VoidGame(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
+
+	league, err := g.leagues.Get(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	voidedGame, err := league.Games.VoidGame(ctx.String("game-link"), ctx.String("reason"), i.Member.User.ID)
+	if err != nil {
+		return err
+	}
+	notice := formatVoidedGame(voidedGame, i.Member.User.ID)
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: notice,
+		},
+	})
+	if err != nil {
+		log.Printf("could not respond to interaction: %v", err)
+	}
+
+	err = g.UpdateLeaderboard(s, i.GuildID)
+	if err != nil {
+		log.Printf("could not update leaderboard: %v", err)
+	}
+
+	gamesChannelID, err := g.getChannelID(s, i.GuildID, model.GamesChannel)
+	if err != nil {
+		log.Printf("could not get games channel ID: %v", err)
+		return nil
+	}
+	if gamesChannelID == i.ChannelID {
+		return nil
+	}
+	_, err = s.ChannelMessageSend(gamesChannelID, notice)
+	if err != nil {
+		log.Printf("could not announce voided game in games channel: %v", err)
+	}
+	return nil
+}
+
+func (g *FanFaction) AddAccount(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
+
+	league, err := g.leagues.Get(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	playerName := ctx.String("player")
+	accountName := ctx.String("bga-name")
+	bgaID := ctx.String("bga-id")
+
+	// Loading the BGA profile can take longer than Discord waits for a response
+	err = ctx.Defer(0)
+	if err != nil {
+		return err
+	}
+
+	err = g.profileScraper.VerifyProfile(accountName, bgaID)
+	if err != nil {
+		return err
+	}
+
+	// The profile is verified without the lock, so a slow BGA page doesn't hold up the other commands
+	g.commandLock.Lock()
+	err = league.Players.AddAccount(playerName, accountName, bgaID)
+	g.commandLock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	g.editResponse(s, i, fmt.Sprintf(
+		"<@%s> added account %s with ID %s to player %s",
+		i.Member.User.ID,
+		accountName,
+		bgaID,
+		playerName,
+	))
+	return nil
+}
+
+func (g *FanFaction) RemoveAccount(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
+
+	league, err := g.leagues.Get(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	accountName := ctx.String("bga-name")
+
+	err = league.Players.RemoveAccount(accountName)
+	if err != nil {
+		return err
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("<@%s> removed account %s", i.Member.User.ID, accountName),
+		},
+	})
+	if err != nil {
+		log.Printf("could not respond to interaction: %v", err)
+	}
+	return nil
+}
+
+func (g *FanFaction) DeactivatePlayer(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
+
+	league, err := g.leagues.Get(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	playerName := ctx.String("name")
+
+	err = league.Players.DeactivatePlayer(playerName)
+	if err != nil {
+		return err
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("<@%s> deactivated player %s", i.Member.User.ID, playerName),
+		},
+	})
+	if err != nil {
+		log.Printf("could not respond to interaction: %v", err)
+	}
+
+	g.updateRegisteredPlayers(s, i.GuildID)
+	return nil
+}
+
+func (g *FanFaction) AnonymizePlayer(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
+
+	league, err := g.leagues.Get(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	playerName := ctx.String("name")
+
+	player, err := league.Players.AnonymizePlayer(playerName)
+	if err != nil {
+		return err
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("<@%s> anonymized a player, their games are now shown as %s", i.Member.User.ID, player.Name),
+		},
+	})
+	if err != nil {
+		log.Printf("could not respond to interaction: %v", err)
+	}
+
+	g.updateRegisteredPlayers(s, i.GuildID)
+	return nil
+}
+
+func (g *FanFaction) SetMinGames(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
+
+	league, err := g.leagues.Get(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	minGames := ctx.Int("games")
+
+	err = league.Seasons.SetMinGames(minGames)
+	if err != nil {
+		return err
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("<@%s> set the minimum number of games to %d", i.Member.User.ID, minGames),
+		},
+	})
+	if err != nil {
+		log.Printf("could not respond to interaction: %v", err)
+	}
+
+	err = g.UpdateLeaderboard(s, i.GuildID)
+	if err != nil {
+		log.Printf("could not update leaderboard: %v", err)
+	}
+	return nil
+}
+
+func (g *FanFaction) ShowLeaderboard(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
+
+	league, err := g.leagues.Get(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	allTime, _ := ctx.OptionalBool("all-time")
+	export, _ := ctx.OptionalBool("export")
+	if export && !allTime {
+		return errors.New("only the all-time leaderboard can be exported")
+	}
+
+	var pages []*discordgo.MessageEmbed
+	var table string
+	var files []*discordgo.File
+	if allTime {
+		leaderboard, err := league.Leaderboard.GetAllTimeLeaderboard()
+		if err != nil {
+			return err
+		}
+		pages = formatAllTimeLeaderboardEmbeds(leaderboard)
+		table = leaderboard.String()
+		if export {
+			csv, err := leaderboard.CSV()
+			if err != nil {
+				return err
+			}
+			files = append(files, &discordgo.File{
+				Name:        "all-time-leaderboard.csv",
+				ContentType: "text/csv",
+				Reader:      bytes.NewReader(csv),
+			})
+		}
+	} else {
+		leaderboard, err := league.Leaderboard.GetLeaderboard()
+		if err != nil {
+			return err
+		}
+		pages = formatLeaderboardEmbeds(leaderboard)
+		table = leaderboard.String()
+	}
+	// The text table is sent next to the embeds for members that turned embeds off, the title, the column names and the
+	// separator are repeated on every page
+	blocks := codeblock.Split(table, 3, codeblock.MaxMessageLength)
+
+	// Pages that don't fit into the response are sent as follow-up messages
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: blocks[0],
+			Embeds:  pages[:1],
+			Files:   files,
+		},
+	})
+	if err != nil {
+		log.Printf("could not respond to interaction: %v", err)
+		return nil
+	}
+	for page := 1; page < max(len(pages), len(blocks)); page++ {
+		params := &discordgo.WebhookParams{}
+		if page < len(blocks) {
+			params.Content = blocks[page]
+		}
+		if page < len(pages) {
+			params.Embeds = pages[page : page+1]
+		}
+		_, err = s.FollowupMessageCreate(i.Interaction, false, params)
+		if err != nil {
+			log.Printf("could not send leaderboard page: %v", err)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (g *FanFaction) ShowProfile(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
+
+	league, err := g.leagues.Get(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	playerName, ok := ctx.OptionalString("player")
+	if !ok {
+		player, linkErr := league.Players.GetLinkedPlayer(i.Member.User.ID)
+		if linkErr != nil {
+			return linkErr
+		}
+		playerName = player.Name
+	}
+
+	profile, err := league.Profile.GetProfile(playerName)
+	if err != nil {
+		return err
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{formatProfile(profile)},
+		},
+	})
+	if err != nil {
+		log.Printf("could not respond to interaction: %v", err)
+	}
+	return nil
+}
+
+func (g *FanFaction) ShowEloChart(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
+
+	league, err := g.leagues.Get(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	playerName, ok := ctx.OptionalString("player")
+	if !ok {
+		player, linkErr := league.Players.GetLinkedPlayer(i.Member.User.ID)
+		if linkErr != nil {
+			return linkErr
+		}
+		playerName = player.Name
+	}
+	playerNames := []string{playerName}
+	for _, optionName := range []string{"compare-with", "compare-with-2"} {
+		if comparedPlayer, ok := ctx.OptionalString(optionName); ok {
+			playerNames = append(playerNames, comparedPlayer)
+		}
+	}
+
+	var histories []*model.EloHistory
+	for _, name := range playerNames {
+		history, historyErr := league.EloHistory.GetEloHistory(name)
+		if historyErr != nil {
+			return historyErr
+		}
+		histories = append(histories, history)
+	}
+
+	chart, err := services.RenderEloChart(histories)
+	if err != nil {
+		return err
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Files: []*discordgo.File{
 				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "id",
-					Description: "The BGA ID of the player.",
-					Required:    true,
+					Name:        "elo.png",
+					ContentType: "image/png",
+					Reader:      bytes.NewReader(chart),
 				},
 			},
 		},
+	})
+	if err != nil {
+		log.Printf("could not respond to interaction: %v", err)
+	}
+	return nil
+}
+
+func (g *FanFaction) ShowHeadToHead(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
+
+	league, err := g.leagues.Get(i.GuildID)
+	if err != nil {
+		return err
 	}
-	commandHandlers := map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate){
-		"register-game": g.RegisterGame,
-		"add-player":    g.AddPlayer,
+
+	playerA := ctx.String("player-a")
+	playerB := ctx.String("player-b")
+
+	headToHead, err := league.HeadToHead.GetHeadToHead(playerA, playerB)
+	if err != nil {
+		return err
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("```\n%s\n```", headToHead.String()),
+		},
+	})
+	if err != nil {
+		log.Printf("could not respond to interaction: %v", err)
 	}
-	return commands, commandHandlers
+	return nil
 }
 
-func (g *FanFaction) RegisterGame(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	g.commandLock.Lock()
-	defer g.commandLock.Unlock()
-	rateLimitUser := g.rateLimitUser(s, i.Member.User.ID, i.Member.Roles)
-	if rateLimitUser {
-		err := errors.New("you are limited to one command per hour, ask a moderator to issue the command for you")
-		g.respondWithError(s, i, err)
-		return
+func (g *FanFaction) JoinSeason(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
+
+	league, err := g.leagues.Get(i.GuildID)
+	if err != nil {
+		return err
 	}
-	log.Println("registering game")
 
-	go func() {
-		responseMessage, err := g.registerGameAsync(s, i)
-		if err != nil {
-			g.sendErrorMessage(s, err, i.Member.User.ID)
-		} else {
-			g.sendAsyncResponse(s, responseMessage)
+	playerName, ok := ctx.OptionalString("player")
+	switch {
+	case !ok:
+		player, linkErr := league.Players.GetLinkedPlayer(i.Member.User.ID)
+		if linkErr != nil {
+			return linkErr
 		}
-	}()
+		playerName = player.Name
+	case !g.isModerator(s, i):
+		return errors.New("you do not have permission to sign up another player")
+	}
 
-	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+	participant, err := league.Seasons.JoinSeason(playerName)
+	if err != nil {
+		return err
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: fmt.Sprintf("<@%s> registering game, please wait", i.Member.User.ID),
+			Content: fmt.Sprintf(
+				"<@%s> signed up %s for %s with %d Elo",
+				i.Member.User.ID,
+				playerName,
+				participant.SeasonName,
+				participant.Elo,
+			),
 		},
 	})
 	if err != nil {
 		log.Printf("could not respond to interaction: %v", err)
 	}
+
+	err = g.UpdateLeaderboard(s, i.GuildID)
+	if err != nil {
+		log.Printf("could not update leaderboard: %v", err)
+	}
+	return nil
 }
 
-func (g *FanFaction) AddPlayer(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	g.commandLock.Lock()
-	defer g.commandLock.Unlock()
-	log.Println("adding player")
+func (g *FanFaction) RequireSignUp(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
 
-	if !g.hasRole(s, i.Member.Roles, "Moderator") {
-		err := errors.New("you do not have permission to add a player")
-		g.respondWithError(s, i, err)
-		return
+	league, err := g.leagues.Get(i.GuildID)
+	if err != nil {
+		return err
 	}
 
-	rateLimitUser := g.rateLimitUser(s, i.Member.User.ID, i.Member.Roles)
-	if rateLimitUser {
-		err := errors.New("you are limited to one command per hour, ask a moderator to issue the command for you")
-		g.respondWithError(s, i, err)
-		return
+	required := ctx.Bool("required")
+
+	err = league.Seasons.SetSignUpRequired(required)
+	if err != nil {
+		return err
+	}
+
+	content := fmt.Sprintf("<@%s> games of all registered players are rated", i.Member.User.ID)
+	if required {
+		content = fmt.Sprintf("<@%s> only games of players that joined the season are rated", i.Member.User.ID)
+	}
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+		},
+	})
+	if err != nil {
+		log.Printf("could not respond to interaction: %v", err)
 	}
+	return nil
+}
+
+func (g *FanFaction) CreateSeason(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
 
-	playerName, err := g.getOption(i, "name")
+	league, err := g.leagues.Get(i.GuildID)
 	if err != nil {
-		g.respondWithError(s, i, err)
-		return
+		return err
 	}
-	playerID, err := g.getOption(i, "id")
+
+	seasonName := ctx.String("name")
+
+	// Rules that are not given are copied from the current season
+	rules, err := league.Seasons.GetNextSeasonRules()
 	if err != nil {
-		g.respondWithError(s, i, err)
-		return
+		return err
+	}
+	rules.SeasonName = seasonName
+	if kFactor, ok := ctx.OptionalInt("k-factor"); ok {
+		rules.KFactor = kFactor
+	}
+	if startElo, ok := ctx.OptionalInt("start-elo"); ok {
+		rules.StartElo = startElo
+	}
+	if eloFloor, ok := ctx.OptionalInt("elo-floor"); ok {
+		rules.EloFloor = eloFloor
+	}
+	if minPlayers, ok := ctx.OptionalInt("min-players"); ok {
+		rules.MinPlayers = minPlayers
+	}
+	if maxGameAgeDays, ok := ctx.OptionalInt("max-game-age-days"); ok {
+		rules.MaxGameAgeDays = maxGameAgeDays
+	}
+	if fanFactions, ok := ctx.OptionalString("fan-factions"); ok {
+		rules.AllowedFanFactionSettings = fanFactionChoices[fanFactions]
 	}
 
-	err = g.playerRepo.InsertPlayer(playerName, playerID)
+	err = league.Seasons.CreateSeason(rules)
 	if err != nil {
-		g.respondWithError(s, i, err)
-		return
+		return err
 	}
 
 	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: fmt.Sprintf("<@%s> added player %s with ID %s", i.Member.User.ID, playerName, playerID),
+			Content: fmt.Sprintf("<@%s> created season %s\n```\n%s```", i.Member.User.ID, seasonName, rules.String()),
 		},
 	})
 	if err != nil {
 		log.Printf("could not respond to interaction: %v", err)
 	}
+	return nil
+}
+
+func (g *FanFaction) CloseSeason(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
 
-	players, err := g.playerRepo.GetPlayers()
+	league, err := g.leagues.Get(i.GuildID)
 	if err != nil {
-		log.Printf("could not get players: %v", err)
-		return
+		return err
 	}
 
-	sort.Slice(players, func(i, j int) bool {
-		return strings.ToLower(players[i].Name) < strings.ToLower(players[j].Name)
+	report, err := league.SeasonReport.CloseSeason()
+	if err != nil {
+		return err
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("<@%s> closed %s", i.Member.User.ID, report.SeasonName),
+		},
 	})
+	if err != nil {
+		log.Printf("could not respond to interaction: %v", err)
+	}
 
-	registeredPlayersChannelID, getChannelErr := getChannelIDByName(s, i.GuildID, "registered-players")
+	leaderboardChannelID, getChannelErr := g.getChannelID(s, i.GuildID, model.LeaderboardChannel)
 	if getChannelErr != nil {
-		log.Printf("could not get registered players channel ID: %v", getChannelErr)
-		return
+		log.Printf("could not get leaderboard channel ID: %v", getChannelErr)
+		return nil
 	}
+	// The report title, the blank line and the standings header are repeated on every message
+	for _, block := range codeblock.Split(report.String(), 5, codeblock.MaxMessageLength) {
+		_, err = s.ChannelMessageSend(leaderboardChannelID, block)
+		if err != nil {
+			log.Printf("could not send season report: %v", err)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (g *FanFaction) Config(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
 
-	err = upsertMessage(s, registeredPlayersChannelID, "Players", formatPlayers(players))
+	settings, err := g.guildSettings.Get(i.GuildID)
 	if err != nil {
-		log.Printf("could not update players: %v", err)
+		return err
+	}
+
+	changed := false
+	for _, channel := range model.Channels {
+		if channelID, ok := ctx.OptionalChannel(string(channel) + "-channel"); ok {
+			settings.ChannelIDs[channel] = channelID
+			changed = true
+		}
+	}
+	if roleID, ok := ctx.OptionalRole("add-moderator-role"); ok {
+		settings.AddModeratorRole(roleID)
+		changed = true
+	}
+	if roleID, ok := ctx.OptionalRole("remove-moderator-role"); ok {
+		settings.RemoveModeratorRole(roleID)
+		changed = true
+	}
+	if minutes, ok := ctx.OptionalInt("rate-limit-minutes"); ok {
+		settings.RateLimit = time.Duration(minutes) * time.Minute
+		changed = true
+	}
+	if publicResults, ok := ctx.OptionalBool("public-results"); ok {
+		settings.PublicResults = publicResults
+		changed = true
+	}
+
+	content := fmt.Sprintf("<@%s> the settings of the server are:\n%s", i.Member.User.ID, settings.String())
+	if changed {
+		err = g.guildSettings.Save(settings)
+		if err != nil {
+			return err
+		}
+		content = fmt.Sprintf("<@%s> updated the settings of the server:\n%s", i.Member.User.ID, settings.String())
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			// Mentioning the roles in the settings would ping them
+			AllowedMentions: &discordgo.MessageAllowedMentions{
+				Users: []string{i.Member.User.ID},
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("could not respond to interaction: %v", err)
 	}
+	return nil
 }
 
-func (g *FanFaction) sendErrorMessage(s *discordgo.Session, err error, playerID string) {
-	log.Printf("could not register game: %v", err)
-	gamesChannelID, getChannelErr := getChannelIDByName(s, g.conf.Discord.GuildID, "games")
-	if getChannelErr != nil {
-		log.Printf("could not get games channel ID: %v", getChannelErr)
+// sendErrorMessage replaces the response with an error only the member who issued the command sees.
+func (g *FanFaction) sendErrorMessage(s *discordgo.Session, i *discordgo.InteractionCreate, err error) {
+	deleteErr := s.InteractionResponseDelete(i.Interaction)
+	if deleteErr != nil {
+		log.Printf("could not delete interaction response: %v", deleteErr)
 	}
-	_, sendErr := s.ChannelMessageSend(gamesChannelID, fmt.Sprintf("<@%s> Error: %s", playerID, err.Error()))
+	_, sendErr := s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Content: fmt.Sprintf("<@%s> Error: %s", i.Member.User.ID, err.Error()),
+		Flags:   discordgo.MessageFlagsEphemeral,
+	})
 	if sendErr != nil {
-		log.Printf("could not send game outcome to games channel: %v", sendErr)
+		log.Printf("could not send error to member: %v", sendErr)
 	}
 }
 
-func (g *FanFaction) sendAsyncResponse(s *discordgo.Session, message string) {
-	gamesChannelID, getChannelErr := getChannelIDByName(s, g.conf.Discord.GuildID, "games")
+// sendGameResult replaces the response with the result. Public results are also announced in the games channel when
+// the response isn't already there.
+func (g *FanFaction) sendGameResult(
+	s *discordgo.Session,
+	i *discordgo.InteractionCreate,
+	settings *model.GuildSettings,
+	message *richMessage,
+) {
+	// Interaction responses can always embed links
+	responded := g.editJobResponse(s, i, message.messageSend(true))
+	if !settings.PublicResults {
+		return
+	}
+
+	gamesChannelID, getChannelErr := g.getChannelID(s, i.GuildID, model.GamesChannel)
 	if getChannelErr != nil {
 		log.Printf("could not get games channel ID: %v", getChannelErr)
+		return
+	}
+	if responded && gamesChannelID == i.ChannelID {
+		return
 	}
-	_, sendErr := s.ChannelMessageSend(gamesChannelID, message)
+	_, sendErr := s.ChannelMessageSendComplex(gamesChannelID, message.messageSend(canEmbedLinks(s, gamesChannelID)))
 	if sendErr != nil {
-		log.Printf("could not send game outcome to games channel: %v", sendErr)
+		log.Printf("could not announce game outcome in games channel: %v", sendErr)
 	}
 }
 
+// respondWithCommandError shows the error of a command to the member who issued it, the response of a deferred
+// command is replaced by the error. Errors of moderator commands are only shown to the moderator.
+func (g *FanFaction) respondWithCommandError(ctx *command.Context, err error) {
+	if !ctx.Deferred() && ctx.Command.Permission == command.Moderator {
+		deferErr := ctx.Defer(discordgo.MessageFlagsEphemeral)
+		if deferErr != nil {
+			log.Printf("could not defer interaction response: %v", deferErr)
+		}
+	}
+	if ctx.Deferred() {
+		g.sendErrorMessage(ctx.Session, ctx.Interaction, err)
+		return
+	}
+	g.respondWithError(ctx.Session, ctx.Interaction, err)
+}
+
 func (g *FanFaction) respondWithError(s *discordgo.Session, i *discordgo.InteractionCreate, err error) {
 	respondErr := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
@@ -221,10 +1204,18 @@ func (g *FanFaction) respondWithError(s *discordgo.Session, i *discordgo.Interac
 	}
 }
 
+func (g *FanFaction) editResponse(s *discordgo.Session, i *discordgo.InteractionCreate, content string) {
+	_, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content: &content,
+	})
+	if err != nil {
+		log.Printf("could not edit interaction response: %v", err)
+	}
+}
+
 func formatPlayers(players []*repomodel.Player) string {
 	var sb strings.Builder
 	sb.WriteString("Players\n")
-	sb.WriteString("```\n")
 	sb.WriteString(fmt.Sprintf("%-20s %-10s %-20s\n", "Name", "ID", "Link"))
 	sb.WriteString(fmt.Sprintf("%-20s %-10s %-20s\n", "--------------------", "----------", "--------------------"))
 	for _, player := range players {
@@ -237,13 +1228,35 @@ func formatPlayers(players []*repomodel.Player) string {
 			),
 		)
 	}
-	sb.WriteString("```\n")
 	return sb.String()
 }
 
-func (g *FanFaction) hasRole(s *discordgo.Session, roleIDs []string, requiredRoleName string) bool {
+// isModerator checks that the member has one of the moderator roles of the guild, until moderator roles are configured
+// the role with the default name is used. Administrators are always moderators so they can't lock themselves out of
+// /config.
+func (g *FanFaction) isModerator(s *discordgo.Session, i *discordgo.InteractionCreate) bool {
+	if i.Member.Permissions&discordgo.PermissionAdministrator != 0 {
+		return true
+	}
+	settings, err := g.guildSettings.Get(i.GuildID)
+	if err != nil {
+		log.Printf("could not get guild settings: %v", err)
+		return false
+	}
+	if len(settings.ModeratorRoleIDs) == 0 {
+		return g.hasRole(s, i.GuildID, i.Member.Roles, model.DefaultModeratorRoleName)
+	}
+	for _, roleID := range i.Member.Roles {
+		if slices.Contains(settings.ModeratorRoleIDs, roleID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *FanFaction) hasRole(s *discordgo.Session, guildID string, roleIDs []string, requiredRoleName string) bool {
 	for _, roleID := range roleIDs {
-		role, err := s.State.Role(g.conf.Discord.GuildID, roleID)
+		role, err := s.State.Role(guildID, roleID)
 		if err != nil {
 			log.Printf("could not get role: %v", err)
 			continue
@@ -255,32 +1268,83 @@ func (g *FanFaction) hasRole(s *discordgo.Session, roleIDs []string, requiredRol
 	return false
 }
 
-func (g *FanFaction) registerGameAsync(s *discordgo.Session, i *discordgo.InteractionCreate) (string, error) {
-	gameLink, err := g.getOption(i, "game-link")
+func (g *FanFaction) registerGame(
+	s *discordgo.Session,
+	i *discordgo.InteractionCreate,
+	gameLink string,
+) (*richMessage, error) {
+	league, err := g.leagues.Get(i.GuildID)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	_, err = url.Parse(gameLink)
 	if err != nil {
-		return "", errors.Wrap(err, "invalid game link")
+		return nil, errors.Wrap(err, "invalid game link")
 	}
 
-	gameOutcome, err := g.gameScraper.ExtractGameOutcome(gameLink)
+	rules, err := league.Seasons.GetRules()
 	if err != nil {
-		return "", errors.Wrap(err, "could not extract game outcome")
+		return nil, err
 	}
 
-	gameResult, err := g.gameService.RegisterGame(gameOutcome)
+	g.editResponse(s, i, fmt.Sprintf("<@%s> loading the game from BGA (1/3)", i.Member.User.ID))
+	var gameOutcome *model.GameOutcome
+	err = retry.Retry(scrapeTimeout, func() error {
+		var extractErr error
+		gameOutcome, extractErr = g.gameScraper.ExtractGameOutcome(gameLink, rules)
+		if extractErr != nil {
+			log.Printf("could not extract game outcome of %s: %v", gameLink, extractErr)
+		}
+		return extractErr
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not extract game outcome")
+	}
+
+	// Other commands can change the players while the game is validated and rated
+	g.commandLock.Lock()
+	defer g.commandLock.Unlock()
+
+	g.editResponse(s, i, fmt.Sprintf("<@%s> validating the game (2/3)", i.Member.User.ID))
+	err = league.Games.ValidateGame(gameOutcome)
 	if err != nil {
-		return "", errors.Wrap(err, "could not register game")
+		return nil, errors.Wrap(err, "could not register game")
 	}
 
-	err = g.UpdateLeaderboard(s, i.GuildID, "leaderboard")
+	g.editResponse(s, i, fmt.Sprintf("<@%s> rating the game (3/3)", i.Member.User.ID))
+	gameResult, err := league.Games.RegisterGame(gameOutcome)
 	if err != nil {
-		return "", errors.Wrap(err, "could not update leaderboard")
+		return nil, errors.Wrap(err, "could not register game")
 	}
 
-	return formatGameResult(i, gameResult, gameOutcome.BGALink()), nil
+	// The game is registered even if the leaderboard can't be updated right now
+	err = g.UpdateLeaderboard(s, i.GuildID)
+	if err != nil {
+		log.Printf("could not update leaderboard: %v", err)
+	}
+
+	return formatGameResultMessage(i, gameResult, gameOutcome), nil
+}
+
+func formatVoidedGame(voidedGame *model.VoidedGame, moderatorID string) string {
+	playerNames := make([]string, len(voidedGame.PlayerNames))
+	for j, name := range voidedGame.PlayerNames {
+		playerNames[j] = escapeMarkdown(name)
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(
+		"<@%s> voided [game %s](<%s>): %s\n",
+		moderatorID,
+		voidedGame.ID,
+		voidedGame.BGALink(),
+		escapeMarkdown(voidedGame.Reason),
+	))
+	sb.WriteString(fmt.Sprintf("The Elo changes of %s were reverted", strings.Join(playerNames, ", ")))
+	if voidedGame.RatedAgain > 0 {
+		sb.WriteString(fmt.Sprintf(" and %d later games were rated again", voidedGame.RatedAgain))
+	}
+	sb.WriteString(".")
+	return sb.String()
 }
 
 func formatGameResult(i *discordgo.InteractionCreate, gameResult []*model.PlayerEloResult, bgaLink string) string {
@@ -293,20 +1357,89 @@ func formatGameResult(i *discordgo.InteractionCreate, gameResult []*model.Player
 		sb.WriteString(fmt.Sprintf("%-5d %-20s %-10d\n", i+1, result.Name, result.EloChange))
 	}
 	sb.WriteString("```\n")
+	var mentions []string
+	for _, result := range gameResult {
+		if result.DiscordID != "" {
+			mentions = append(mentions, fmt.Sprintf("<@%s>", result.DiscordID))
+		}
+	}
+	if len(mentions) > 0 {
+		sb.WriteString(fmt.Sprintf("Well played %s!\n", strings.Join(mentions, " ")))
+	}
 	return sb.String()
 }
 
-func (g *FanFaction) getOption(i *discordgo.InteractionCreate, optionName string) (string, error) {
-	options := i.ApplicationCommandData().Options
-	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
-	for _, opt := range options {
-		optionMap[opt.Name] = opt
+func formatProfile(profile *model.PlayerProfile) *discordgo.MessageEmbed {
+	rank := "Unranked"
+	if profile.Rank > 0 {
+		rank = fmt.Sprintf("#%d", profile.Rank)
 	}
-	gameLink, ok := optionMap[optionName]
-	if !ok {
-		return "", errors.New("game-link option not provided")
+	fields := []*discordgo.MessageEmbedField{
+		{Name: "Elo", Value: strconv.Itoa(profile.Elo), Inline: true},
+		{Name: "Rank", Value: rank, Inline: true},
+		{Name: "Games played", Value: strconv.Itoa(profile.GamesPlayed), Inline: true},
+	}
+	if profile.GamesPlayed > 0 {
+		positions := make([]string, len(profile.PositionCounts))
+		for i, count := range profile.PositionCounts {
+			positions[i] = fmt.Sprintf("%s: %d", ordinal(i+1), count)
+		}
+		streak := fmt.Sprintf("%d without a win", profile.CurrentStreak)
+		if profile.StreakIsWin {
+			streak = fmt.Sprintf("%d wins", profile.CurrentStreak)
+		}
+		var recentGames strings.Builder
+		for _, game := range profile.RecentGames {
+			recentGames.WriteString(fmt.Sprintf(
+				"[%s](https://boardgamearena.com/table?table=%s) %s, %d VP, %+d Elo\n",
+				game.GameID,
+				game.GameID,
+				ordinal(game.Position),
+				game.Score,
+				game.EloChange,
+			))
+		}
+		fields = append(fields,
+			&discordgo.MessageEmbedField{Name: "Finishing positions", Value: strings.Join(positions, ", ")},
+			&discordgo.MessageEmbedField{Name: "Average VP", Value: fmt.Sprintf("%.1f", profile.AverageScore), Inline: true},
+			&discordgo.MessageEmbedField{Name: "Best VP", Value: strconv.Itoa(profile.BestScore), Inline: true},
+			&discordgo.MessageEmbedField{
+				Name:   "Biggest Elo swing",
+				Value:  fmt.Sprintf("%+d", profile.BiggestEloSwing),
+				Inline: true,
+			},
+			&discordgo.MessageEmbedField{Name: "Current streak", Value: streak},
+			&discordgo.MessageEmbedField{Name: "Last games", Value: recentGames.String()},
+		)
+	}
+	return &discordgo.MessageEmbed{
+		Title:  profile.PlayerName,
+		Footer: &discordgo.MessageEmbedFooter{Text: profile.SeasonName},
+		Fields: fields,
+	}
+}
+
+func ordinal(position int) string {
+	switch position {
+	case 1:
+		return "1st"
+	case 2: //nolint:mnd // Second place
+		return "2nd"
+	case 3: //nolint:mnd // Third place
+		return "3rd"
+	default:
+		return fmt.Sprintf("%dth", position)
+	}
+}
+
+// getComponentArgument returns the ID after the handler name in the custom ID of the component.
+func getComponentArgument(i *discordgo.InteractionCreate) (int, error) {
+	_, argument, _ := strings.Cut(i.MessageComponentData().CustomID, ":")
+	id, err := strconv.Atoi(argument)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid component ID")
 	}
-	return gameLink.StringValue(), nil
+	return id, nil
 }
 
 func getChannelIDByName(s *discordgo.Session, guildID, channelName string) (string, error) {
@@ -324,13 +1457,30 @@ func getChannelIDByName(s *discordgo.Session, guildID, channelName string) (stri
 	return "", fmt.Errorf("channel with name %s not found", channelName)
 }
 
-func (g *FanFaction) UpdateLeaderboard(s *discordgo.Session, guildID, channelName string) error {
-	leaderboard, err := g.leaderboardService.GetLeaderboard()
+// getChannelID returns the channel configured in the guild settings, a channel that is not configured is looked up by
+// its default name.
+func (g *FanFaction) getChannelID(s *discordgo.Session, guildID string, channel model.Channel) (string, error) {
+	settings, err := g.guildSettings.Get(guildID)
+	if err != nil {
+		return "", errors.Wrap(err, "could not get guild settings")
+	}
+	if channelID := settings.ChannelIDs[channel]; channelID != "" {
+		return channelID, nil
+	}
+	return getChannelIDByName(s, guildID, string(channel))
+}
+
+func (g *FanFaction) UpdateLeaderboard(s *discordgo.Session, guildID string) error {
+	league, err := g.leagues.Get(guildID)
+	if err != nil {
+		return err
+	}
+	leaderboard, err := league.Leaderboard.GetLeaderboard()
 	if err != nil {
 		return err
 	}
 
-	leaderboardChannelID, getChannelErr := getChannelIDByName(s, guildID, channelName)
+	leaderboardChannelID, getChannelErr := g.getChannelID(s, guildID, model.LeaderboardChannel)
 	if getChannelErr != nil {
 		return getChannelErr
 	}
@@ -338,28 +1488,92 @@ func (g *FanFaction) UpdateLeaderboard(s *discordgo.Session, guildID, channelNam
 	leaderboardUpdate := leaderboard.String()
 	log.Printf("Leaderboard: %s", leaderboardUpdate)
 
-	err = upsertMessage(s, leaderboardChannelID, "Leaderboard", fmt.Sprintf("```\n%s\n```", leaderboardUpdate))
+	pages := managedPages(s, leaderboardChannelID, formatLeaderboardEmbeds(leaderboard), leaderboardUpdate)
+	return g.updatePages(s, guildID, leaderboardChannelID, services.LeaderboardMessage, "Leaderboard", pages)
+}
+
+// updatePages brings the pages of the managed message in the channel up to date. Pages are edited in place, sent when
+// the table has grown and deleted when it has shrunk. Until the pages of a message are tracked the message the bot
+// sent before is looked up by its title.
+func (g *FanFaction) updatePages(
+	s *discordgo.Session,
+	guildID, channelID, name, title string,
+	pages []*discordgo.MessageSend,
+) error {
+	tracked, err := g.managedMessages.GetPages(guildID, name)
 	if err != nil {
 		return err
 	}
-	return nil
-}
+	if len(tracked) == 0 {
+		if messageID, findErr := getMessageIDContaining(s, channelID, title); findErr == nil {
+			tracked = []*repomodel.ManagedMessage{{ChannelID: channelID, MessageID: messageID}}
+		}
+	}
 
-func upsertMessage(s *discordgo.Session, channelID, messageHeader, newContent string) error {
-	messageID, err := getMessageIDContaining(s, channelID, messageHeader)
-	if err != nil {
-		_, err = s.ChannelMessageSend(channelID, newContent)
-		if err != nil {
-			return errors.Wrap(err, "could not send message")
+	for page, content := range pages {
+		messageID := ""
+		if page < len(tracked) {
+			if tracked[page].ChannelID == channelID {
+				edit := discordgo.NewMessageEdit(channelID, tracked[page].MessageID)
+				edit.Content = &content.Content
+				edit.Embeds = &content.Embeds
+				_, editErr := s.ChannelMessageEditComplex(edit)
+				if editErr == nil {
+					messageID = tracked[page].MessageID
+				} else {
+					log.Printf("could not edit page %d of %s, sending it again: %v", page+1, name, editErr)
+				}
+			} else {
+				// The channel was changed in the settings
+				deleteManagedMessage(s, tracked[page])
+			}
 		}
-	} else {
-		_, err = s.ChannelMessageEdit(channelID, messageID, newContent)
+		if messageID == "" {
+			message, sendErr := s.ChannelMessageSendComplex(channelID, content)
+			if sendErr != nil {
+				return errors.Wrap(sendErr, "could not send message")
+			}
+			messageID = message.ID
+		}
+		err = g.managedMessages.SavePage(guildID, name, page, channelID, messageID)
 		if err != nil {
-			return errors.Wrap(err, "could not edit message")
+			return err
 		}
 	}
 
-	return nil
+	for _, message := range tracked[min(len(pages), len(tracked)):] {
+		deleteManagedMessage(s, message)
+	}
+	return g.managedMessages.RemovePagesFrom(guildID, name, len(pages))
+}
+
+// managedPages returns a message for every embed, in channels the bot can't embed links in the table is sent as text
+// instead.
+func managedPages(
+	s *discordgo.Session,
+	channelID string,
+	embeds []*discordgo.MessageEmbed,
+	table string,
+) []*discordgo.MessageSend {
+	var pages []*discordgo.MessageSend
+	if canEmbedLinks(s, channelID) {
+		for _, embed := range embeds {
+			pages = append(pages, &discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{embed}})
+		}
+		return pages
+	}
+	// The title, the column names and the separator are repeated on every page
+	for _, block := range codeblock.Split(table, 3, codeblock.MaxMessageLength) {
+		pages = append(pages, &discordgo.MessageSend{Content: block})
+	}
+	return pages
+}
+
+func deleteManagedMessage(s *discordgo.Session, message *repomodel.ManagedMessage) {
+	err := s.ChannelMessageDelete(message.ChannelID, message.MessageID)
+	if err != nil {
+		log.Printf("could not delete message %s: %v", message.MessageID, err)
+	}
 }
 
 func getMessageIDContaining(s *discordgo.Session, channelID, searchString string) (string, error) {
@@ -378,19 +1592,53 @@ func getMessageIDContaining(s *discordgo.Session, channelID, searchString string
 	return "", fmt.Errorf("message containing %s not found", searchString)
 }
 
-func (g *FanFaction) rateLimitUser(s *discordgo.Session, discordID string, roles []string) bool {
-	if g.hasRole(s, roles, "Moderator") {
-		return false
+// commandRateLimit returns the rate limit of the command, commands without a window use the window of the guild.
+func (g *FanFaction) commandRateLimit(ctx *command.Context) model.RateLimit {
+	return g.rateLimit(ctx.GuildID(), ctx.Command.RateLimit)
+}
+
+// rateLimit returns the rate limit of a command in the guild, commands without a window use the window of the guild.
+func (g *FanFaction) rateLimit(guildID string, rateLimit command.RateLimit) model.RateLimit {
+	limit := model.RateLimit{Uses: rateLimit.Uses, Window: rateLimit.Window}
+	if limit.Window > 0 {
+		return limit
+	}
+	limit.Window = model.DefaultRateLimit
+	settings, err := g.guildSettings.Get(guildID)
+	if err != nil {
+		log.Printf("could not get guild settings: %v", err)
+	} else {
+		limit.Window = settings.RateLimit
 	}
+	return limit
+}
 
-	lastCommandTime, ok := g.lastCommandByUser[discordID]
-	if ok {
-		elapsed := time.Since(lastCommandTime)
-		if elapsed < 60*time.Minute {
-			return true
-		}
+// allowCommand checks that the member has uses of the command left, members with a moderator role are not limited.
+func (g *FanFaction) allowCommand(ctx *command.Context) error {
+	if g.isModerator(ctx.Session, ctx.Interaction) {
+		return nil
+	}
+	limit := g.commandRateLimit(ctx)
+	retryAt, err := g.rateLimiter.RetryAt(ctx.GuildID(), ctx.UserID(), ctx.Name, limit, time.Now())
+	if err != nil {
+		return err
 	}
+	if retryAt.IsZero() {
+		return nil
+	}
+	return fmt.Errorf(
+		"you can use /%s %d times every %s, try again <t:%d:R> or ask a moderator to issue the command for you",
+		ctx.Name,
+		limit.Uses,
+		limit.Window,
+		retryAt.Unix(),
+	)
+}
 
-	g.lastCommandByUser[discordID] = time.Now()
-	return false
+// recordCommand counts a successful use of the command against the rate limit of the member.
+func (g *FanFaction) recordCommand(ctx *command.Context) error {
+	if g.isModerator(ctx.Session, ctx.Interaction) {
+		return nil
+	}
+	return g.rateLimiter.Record(ctx.GuildID(), ctx.UserID(), ctx.Name, g.commandRateLimit(ctx), time.Now())
 }
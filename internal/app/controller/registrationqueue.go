@@ -0,0 +1,267 @@
+package controller
+
+import (
+	"fmt"
+	"log"
+	"runtime/debug"
+	"strings"
+	"time"
+	"tmff-discord-app/internal/app/controller/command"
+	"tmff-discord-app/internal/app/services/model"
+	"tmff-discord-app/pkg/codeblock"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+const (
+	// scrapeTimeout is how long the worker retries to load a game from BGA.
+	scrapeTimeout = 2 * time.Minute
+	// registrationPollInterval is how often the worker checks the queue when nobody wakes it up.
+	registrationPollInterval = time.Minute
+	// maxJobErrorLength is the most characters of the error of a failed job that /queue show lists.
+	maxJobErrorLength = 100
+)
+
+// RunRegistrationWorker registers the queued games one at a time until stop is closed. A job that is interrupted is
+// resumed on the next start.
+func (g *FanFaction) RunRegistrationWorker(s *discordgo.Session, stop <-chan struct{}) {
+	ticker := time.NewTicker(registrationPollInterval)
+	defer ticker.Stop()
+	for {
+		g.processRegistrationJobs(s, stop)
+		select {
+		case <-stop:
+			return
+		case <-g.jobQueued:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (g *FanFaction) wakeRegistrationWorker() {
+	select {
+	case g.jobQueued <- struct{}{}:
+	default:
+		// The worker is already awake
+	}
+}
+
+func (g *FanFaction) processRegistrationJobs(s *discordgo.Session, stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		job, err := g.registrationQueue.Next()
+		if err != nil {
+			log.Printf("could not get next registration job: %v", err)
+			return
+		}
+		if job == nil {
+			return
+		}
+		g.processRegistrationJob(s, job)
+	}
+}
+
+// processRegistrationJob registers the game of the job. The worker runs outside the command middleware, so a panic is
+// recovered here and fails the job instead of stopping the bot.
+func (g *FanFaction) processRegistrationJob(s *discordgo.Session, job *model.RegistrationJob) {
+	i := jobInteraction(job)
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("registration job %d panicked: %v\n%s", job.ID, r, debug.Stack())
+			g.failRegistrationJob(s, i, job, errors.New("something went wrong registering the game"))
+		}
+	}()
+
+	settings, err := g.guildSettings.Get(job.GuildID)
+	if err != nil {
+		g.failRegistrationJob(s, i, job, err)
+		return
+	}
+	responseMessage, err := g.registerGame(s, i, job.GameLink)
+	if err != nil {
+		g.failRegistrationJob(s, i, job, err)
+		return
+	}
+	err = g.registrationQueue.Rated(job)
+	if err != nil {
+		log.Printf("could not mark registration job %d as rated: %v", job.ID, err)
+	}
+	// Only rated games count against the rate limit of /register-game
+	err = g.rateLimiter.Record(
+		job.GuildID,
+		job.UserID,
+		"register-game",
+		g.rateLimit(job.GuildID, registerGameRateLimit),
+		time.Now(),
+	)
+	if err != nil {
+		log.Printf("could not record use of /register-game: %v", err)
+	}
+	g.sendGameResult(s, i, settings, responseMessage)
+}
+
+func (g *FanFaction) failRegistrationJob(
+	s *discordgo.Session,
+	i *discordgo.InteractionCreate,
+	job *model.RegistrationJob,
+	cause error,
+) {
+	log.Printf("registration job %d failed: %v", job.ID, cause)
+	err := g.registrationQueue.Failed(job, cause)
+	if err != nil {
+		log.Printf("could not mark registration job %d as failed: %v", job.ID, err)
+	}
+	g.editJobResponse(s, i, &discordgo.MessageSend{
+		Content: fmt.Sprintf(
+			"<@%s> Error: %s\nA moderator can retry it with `/queue retry job:%d`.",
+			job.UserID,
+			cause.Error(),
+			job.ID,
+		),
+	})
+}
+
+// jobInteraction returns the interaction that queued or retried the job, so the worker can update its response.
+func jobInteraction(job *model.RegistrationJob) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type:      discordgo.InteractionApplicationCommand,
+			AppID:     job.AppID,
+			Token:     job.InteractionToken,
+			GuildID:   job.GuildID,
+			ChannelID: job.ChannelID,
+			Member:    &discordgo.Member{User: &discordgo.User{ID: job.UserID}},
+		},
+	}
+}
+
+// editJobResponse replaces the response with the message. Interaction tokens expire after 15 minutes, when the
+// response can't be edited anymore the member gets the message as a direct message. It returns whether the response
+// was edited.
+func (g *FanFaction) editJobResponse(
+	s *discordgo.Session,
+	i *discordgo.InteractionCreate,
+	message *discordgo.MessageSend,
+) bool {
+	_, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content: &message.Content,
+		Embeds:  &message.Embeds,
+	})
+	if err == nil {
+		return true
+	}
+	log.Printf("could not edit interaction response, sending direct message: %v", err)
+	channel, err := s.UserChannelCreate(i.Member.User.ID)
+	if err != nil {
+		log.Printf("could not open direct message channel: %v", err)
+		return false
+	}
+	_, err = s.ChannelMessageSendComplex(channel.ID, message)
+	if err != nil {
+		log.Printf("could not send direct message: %v", err)
+	}
+	return false
+}
+
+func (g *FanFaction) ShowQueue(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
+
+	pending, err := g.registrationQueue.GetPendingJobs(i.GuildID)
+	if err != nil {
+		return err
+	}
+	failed, err := g.registrationQueue.GetFailedJobs(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: formatRegistrationQueue(pending, failed),
+			// Listing the jobs shouldn't ping the members who queued them
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		},
+	})
+	if err != nil {
+		log.Printf("could not respond to interaction: %v", err)
+	}
+	return nil
+}
+
+func (g *FanFaction) RetryRegistrationJob(ctx *command.Context) error {
+	s, i := ctx.Session, ctx.Interaction
+
+	err := ctx.Defer(0)
+	if err != nil {
+		return err
+	}
+	// The worker reports the progress and the result in the response of the moderator
+	job, err := g.registrationQueue.Retry(i.GuildID, ctx.Int("job"), i.AppID, i.Token)
+	if err != nil {
+		return err
+	}
+	position, err := g.registrationQueue.Position(job)
+	if err != nil {
+		log.Printf("could not get position of registration job %d: %v", job.ID, err)
+	}
+	g.editResponse(s, i, fmt.Sprintf("<@%s> job %d is queued again (position %d)", i.Member.User.ID, job.ID, position+1))
+	g.wakeRegistrationWorker()
+	return nil
+}
+
+// formatRegistrationQueue lists the jobs, errors are shortened and jobs that don't fit in a message are left out.
+func formatRegistrationQueue(pending, failed []*model.RegistrationJob) string {
+	var lines []string
+	lines = append(lines, "**Pending games**")
+	if len(pending) == 0 {
+		lines = append(lines, "The queue is empty.")
+	}
+	for _, job := range pending {
+		lines = append(lines, fmt.Sprintf(
+			"`%d` <%s> by <@%s>, %s since <t:%d:R>",
+			job.ID,
+			job.GameLink,
+			job.UserID,
+			job.Status,
+			job.UpdatedAt.Unix(),
+		))
+	}
+	lines = append(lines, "**Failed games**")
+	if len(failed) == 0 {
+		lines = append(lines, "No game failed.")
+	}
+	for _, job := range failed {
+		lines = append(lines, fmt.Sprintf(
+			"`%d` <%s> by <@%s> after %d attempts <t:%d:R>: %s",
+			job.ID,
+			job.GameLink,
+			job.UserID,
+			job.Attempts,
+			job.UpdatedAt.Unix(),
+			shortenText(job.Error, maxJobErrorLength),
+		))
+	}
+
+	var sb strings.Builder
+	for _, line := range lines {
+		if sb.Len()+len(line)+1 > codeblock.MaxMessageLength {
+			break
+		}
+		sb.WriteString(line + "\n")
+	}
+	return sb.String()
+}
+
+func shortenText(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return string(runes[:length-1]) + "…"
+}
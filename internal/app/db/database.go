@@ -6,13 +6,26 @@ import (
 	"github.com/golang-migrate/migrate/v4/database/sqlite3"
 	bindata "github.com/golang-migrate/migrate/v4/source/go_bindata"
 	"github.com/jmoiron/sqlx"
+	sqlite3driver "github.com/mattn/go-sqlite3"
 	"github.com/pkg/errors"
 	"tmff-discord-app/db"
 	"tmff-discord-app/internal/app/config"
+	"tmff-discord-app/pkg/names"
 )
 
+// driverName is the sqlite3 driver with the functions the queries and migrations rely on
+const driverName = "sqlite3_tmff"
+
+func init() {
+	sql.Register(driverName, &sqlite3driver.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3driver.SQLiteConn) error {
+			return conn.RegisterFunc("normalize_name", names.Normalize, true)
+		},
+	})
+}
+
 func SetupDatabase(conf *config.Config) (*sqlx.DB, error) {
-	sqlDB, err := sql.Open("sqlite3", conf.DBFile)
+	sqlDB, err := sql.Open(driverName, conf.DBFile)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not open database")
 	}
@@ -24,7 +37,9 @@ func SetupDatabase(conf *config.Config) (*sqlx.DB, error) {
 		return nil, errors.Wrap(err, "could not enable foreign key constraints")
 	}
 
-	driver, err := sqlite3.WithInstance(sqlDB, &sqlite3.Config{})
+	// Migrations manage their own transactions, foreign keys can only be turned off outside of a transaction. Every
+	// migration with more than one statement wraps them in BEGIN and COMMIT.
+	driver, err := sqlite3.WithInstance(sqlDB, &sqlite3.Config{NoTxWrap: true})
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create sqlite3 driver")
 	}
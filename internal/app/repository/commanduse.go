@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"time"
+	"tmff-discord-app/internal/app/repository/model"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+const (
+	getCommandUsesSinceQuery = `
+		SELECT id, guild_id, user_id, command, used_at 
+		FROM command_uses 
+		WHERE guild_id = $1 AND user_id = $2 AND command = $3 AND used_at > $4 
+		ORDER BY used_at ASC`
+	insertCommandUseQuery = `
+		INSERT INTO command_uses (guild_id, user_id, command, used_at) 
+		VALUES (:guild_id, :user_id, :command, :used_at)`
+	deleteCommandUsesBeforeQuery = `
+		DELETE FROM command_uses 
+		WHERE guild_id = $1 AND user_id = $2 AND command = $3 AND used_at <= $4`
+)
+
+type CommandUse struct {
+	db           *sqlx.DB
+	queryTimeout *time.Duration
+}
+
+func NewCommandUse(db *sqlx.DB, queryTimeout *time.Duration) *CommandUse {
+	return &CommandUse{
+		db:           db,
+		queryTimeout: queryTimeout,
+	}
+}
+
+// GetUsesSince returns the uses of the command by the user after the time, oldest first. Times are compared in UTC.
+func (r *CommandUse) GetUsesSince(guildID, userID, command string, since time.Time) ([]*model.CommandUse, error) {
+	var uses []*model.CommandUse
+	err := r.db.Select(&uses, getCommandUsesSinceQuery, guildID, userID, command, since.UTC())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query command uses")
+	}
+	return uses, nil
+}
+
+func (r *CommandUse) Insert(use *model.CommandUse) error {
+	use.UsedAt = use.UsedAt.UTC()
+	_, err := r.db.NamedExec(insertCommandUseQuery, use)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert command use")
+	}
+	return nil
+}
+
+// DeleteBefore forgets the uses of the command by the user up to the time.
+func (r *CommandUse) DeleteBefore(guildID, userID, command string, before time.Time) error {
+	_, err := r.db.Exec(deleteCommandUsesBeforeQuery, guildID, userID, command, before.UTC())
+	if err != nil {
+		return errors.Wrap(err, "failed to delete command uses")
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+	"tmff-discord-app/internal/app/repository"
+	"tmff-discord-app/internal/app/repository/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandUse(t *testing.T) {
+	t.Parallel()
+	t.Run("Test uses since", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		commandUseRepo := repository.NewCommandUse(dbx, &queryTimeout)
+		start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+		for minutes := range 3 {
+			err := commandUseRepo.Insert(&model.CommandUse{
+				GuildID: testGuildID,
+				UserID:  "user-1",
+				Command: "register-game",
+				UsedAt:  start.Add(time.Duration(minutes*20) * time.Minute),
+			})
+			require.NoError(t, err)
+		}
+		err := commandUseRepo.Insert(&model.CommandUse{
+			GuildID: testGuildID,
+			UserID:  "user-2",
+			Command: "register-game",
+			UsedAt:  start,
+		})
+		require.NoError(t, err)
+
+		uses, err := commandUseRepo.GetUsesSince(testGuildID, "user-1", "register-game", start.Add(10*time.Minute))
+		require.NoError(t, err)
+		require.Len(t, uses, 2)
+		assert.True(t, start.Add(20*time.Minute).Equal(uses[0].UsedAt), uses[0].UsedAt)
+		assert.True(t, start.Add(40*time.Minute).Equal(uses[1].UsedAt), uses[1].UsedAt)
+
+		// Times in other zones are compared in UTC
+		since := start.Add(30 * time.Minute).In(time.FixedZone("CEST", 2*60*60))
+		uses, err = commandUseRepo.GetUsesSince(testGuildID, "user-1", "register-game", since)
+		require.NoError(t, err)
+		assert.Len(t, uses, 1)
+
+		err = commandUseRepo.DeleteBefore(testGuildID, "user-1", "register-game", start.Add(20*time.Minute))
+		require.NoError(t, err)
+		uses, err = commandUseRepo.GetUsesSince(testGuildID, "user-1", "register-game", start.Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Len(t, uses, 1)
+		uses, err = commandUseRepo.GetUsesSince(testGuildID, "user-2", "register-game", start.Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Len(t, uses, 1)
+	})
+}
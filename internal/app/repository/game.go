@@ -17,11 +17,11 @@ var (
 
 const (
 	insertGameQuery = `
-		INSERT INTO games (bga_id, season_name) 
-		VALUES ($1, $2)`
+		INSERT INTO games (bga_id, season_name, guild_id) 
+		VALUES ($1, $2, $3)`
 	insertGameParticipantQuery = `
-		INSERT INTO game_participants (game_id, player_id, score, elo_change, elo_before) 
-		VALUES ($1, $2, $3, $4, $5)`
+		INSERT INTO game_participants (game_id, player_id, score, elo_change, elo_before, guild_id) 
+		VALUES ($1, $2, $3, $4, $5, $6)`
 	selectParticipantsQuery = `
 		SELECT 
 			id, 
@@ -34,20 +34,110 @@ const (
 		FROM 
 			game_participants 
 		WHERE 
-			game_id = $1`
-	selectGameQuery = `SELECT bga_id, season_name, created_at FROM games WHERE bga_id = $1`
+			game_id = $1 AND guild_id = $2`
+	selectGameQuery = `
+		SELECT bga_id, season_name, voided_at, void_reason, created_at 
+		FROM games 
+		WHERE bga_id = $1 AND guild_id = $2`
+	selectAllGameParticipantsQuery = `
+		SELECT 
+			gp.id, 
+			gp.game_id, 
+			g.season_name, 
+			gp.player_id, 
+			gp.score, 
+			gp.elo_change, 
+			gp.elo_before, 
+			gp.created_at 
+		FROM 
+			game_participants gp 
+			JOIN games g ON g.guild_id = gp.guild_id AND g.bga_id = gp.game_id 
+		WHERE 
+			g.guild_id = $1 
+			AND g.voided_at IS NULL 
+		ORDER BY 
+			gp.id ASC`
+	selectSeasonGameParticipantsQuery = `
+		SELECT 
+			gp.id, 
+			gp.game_id, 
+			gp.player_id, 
+			gp.score, 
+			gp.elo_change, 
+			gp.elo_before, 
+			gp.created_at 
+		FROM 
+			game_participants gp 
+			JOIN games g ON g.guild_id = gp.guild_id AND g.bga_id = gp.game_id 
+		WHERE 
+			g.season_name = $1 
+			AND g.guild_id = $2 
+			AND g.voided_at IS NULL 
+		ORDER BY 
+			gp.id ASC`
+	selectPlayerSeasonGameParticipantsQuery = `
+		SELECT 
+			gp.id, 
+			gp.game_id, 
+			g.season_name, 
+			gp.player_id, 
+			gp.score, 
+			gp.elo_change, 
+			gp.elo_before, 
+			gp.created_at 
+		FROM 
+			game_participants gp 
+			JOIN games g ON g.guild_id = gp.guild_id AND g.bga_id = gp.game_id 
+		WHERE 
+			g.season_name = $1 
+			AND g.voided_at IS NULL 
+			AND gp.game_id IN (SELECT game_id FROM game_participants WHERE player_id = $2) 
+			AND g.guild_id = $3 
+		ORDER BY 
+			gp.id ASC`
+	selectHeadToHeadGamesQuery = `
+		SELECT 
+			a.game_id, 
+			g.season_name, 
+			a.score AS score_a, 
+			b.score AS score_b, 
+			a.elo_before AS elo_before_a, 
+			b.elo_before AS elo_before_b, 
+			g.created_at 
+		FROM 
+			game_participants a 
+			JOIN game_participants b ON b.guild_id = a.guild_id AND b.game_id = a.game_id 
+			JOIN games g ON g.guild_id = a.guild_id AND g.bga_id = a.game_id 
+		WHERE 
+			a.player_id = $1 
+			AND b.player_id = $2 
+			AND g.guild_id = $3 
+			AND g.voided_at IS NULL 
+		ORDER BY 
+			a.id ASC`
+	voidGameQuery = `
+		UPDATE games 
+		SET voided_at = CURRENT_TIMESTAMP, voided_by = $1, void_reason = $2 
+		WHERE bga_id = $3 AND guild_id = $4 AND voided_at IS NULL`
+	resetSeasonParticipantsQuery = `
+		UPDATE season_participants 
+		SET elo = $1, games_played = 0 
+		WHERE season_name = $2 AND guild_id = $3`
 )
 
 type Game struct {
 	db           *sqlx.DB
 	queryTimeout *time.Duration
+	guildID      string
 	seasonName   string
 }
 
-func NewGame(db *sqlx.DB, queryTimeout *time.Duration, seasonName string) *Game {
+// NewGame returns the games of the league of the guild, new games are registered in the season.
+func NewGame(db *sqlx.DB, queryTimeout *time.Duration, guildID, seasonName string) *Game {
 	return &Game{
 		db:           db,
 		queryTimeout: queryTimeout,
+		guildID:      guildID,
 		seasonName:   seasonName,
 	}
 }
@@ -65,7 +155,7 @@ func (r *Game) CreateGameWithParticipants(gameID string, participants []*model.G
 	}(tx)
 
 	// Insert game
-	_, err = tx.Exec(insertGameQuery, gameID, r.seasonName)
+	_, err = tx.Exec(insertGameQuery, gameID, r.seasonName, r.guildID)
 	if err != nil {
 		if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
 			return errors.New("season does not exist")
@@ -85,6 +175,7 @@ func (r *Game) CreateGameWithParticipants(gameID string, participants []*model.G
 			participant.Score,
 			participant.EloChange,
 			participant.EloBefore,
+			r.guildID,
 		)
 		if err != nil {
 			if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
@@ -108,7 +199,7 @@ func (r *Game) GetGameWithParticipants(gameID string) (*model.GameWithParticipan
 	var participants []model.GameParticipant
 
 	// Get game details
-	err := r.db.Get(&game, selectGameQuery, gameID)
+	err := r.db.Get(&game, selectGameQuery, gameID, r.guildID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrGameNotFound
@@ -117,7 +208,7 @@ func (r *Game) GetGameWithParticipants(gameID string) (*model.GameWithParticipan
 	}
 
 	// Get game participants
-	rows, err := r.db.Queryx(selectParticipantsQuery, gameID)
+	rows, err := r.db.Queryx(selectParticipantsQuery, gameID, r.guildID)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to query game participants")
 	}
@@ -139,9 +230,115 @@ func (r *Game) GetGameWithParticipants(gameID string) (*model.GameWithParticipan
 	gameWithParticipants := &model.GameWithParticipants{
 		GameID:       game.BGAID,
 		SeasonName:   game.SeasonName,
+		VoidedAt:     game.VoidedAt,
+		VoidReason:   game.VoidReason,
 		CreatedAt:    game.CreatedAt,
 		Participants: participants,
 	}
 
 	return gameWithParticipants, nil
 }
+
+// GetSeasonGameParticipants returns the participants of every game in the season in the order they were registered.
+func (r *Game) GetSeasonGameParticipants() ([]*model.GameParticipant, error) {
+	var participants []*model.GameParticipant
+	err := r.db.Select(&participants, selectSeasonGameParticipantsQuery, r.seasonName, r.guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query season game participants")
+	}
+	return participants, nil
+}
+
+// GetAllGameParticipants returns the participants of every game in every season in the order they were registered.
+func (r *Game) GetAllGameParticipants() ([]*model.GameParticipant, error) {
+	var participants []*model.GameParticipant
+	err := r.db.Select(&participants, selectAllGameParticipantsQuery, r.guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query game participants")
+	}
+	return participants, nil
+}
+
+// GetPlayerSeasonGameParticipants returns every participant of the games the player played in the season, in the
+// order they were registered.
+func (r *Game) GetPlayerSeasonGameParticipants(playerID int) ([]*model.GameParticipant, error) {
+	var participants []*model.GameParticipant
+	err := r.db.Select(&participants, selectPlayerSeasonGameParticipantsQuery, r.seasonName, playerID, r.guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query player game participants")
+	}
+	return participants, nil
+}
+
+// GetHeadToHeadGames returns the games of every season both players played in, in the order they were registered.
+func (r *Game) GetHeadToHeadGames(playerAID, playerBID int) ([]*model.HeadToHeadGame, error) {
+	var games []*model.HeadToHeadGame
+	err := r.db.Select(&games, selectHeadToHeadGamesQuery, playerAID, playerBID, r.guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query head to head games")
+	}
+	return games, nil
+}
+
+// VoidGame marks the game void and stores the season rated again without it. Every participant of the season starts
+// over from the start Elo, the participants that still have games get their new standings.
+func (r *Game) VoidGame(
+	gameID, voidedBy, reason string,
+	startElo int,
+	gameParticipants []*model.GameParticipant,
+	seasonParticipants []*model.SeasonParticipant,
+) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer func(tx *sqlx.Tx) {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil {
+			log.Printf("failed to rollback transaction: %v", rollbackErr)
+		}
+	}(tx)
+
+	result, err := tx.Exec(voidGameQuery, voidedBy, reason, gameID, r.guildID)
+	if err != nil {
+		return errors.Wrap(err, "failed to void game")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to get affected rows")
+	}
+	if rowsAffected == 0 {
+		return ErrGameNotFound
+	}
+
+	for _, participant := range gameParticipants {
+		_, err = tx.Exec(updateGameParticipantEloQuery, participant.EloBefore, participant.EloChange, participant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to update game participant")
+		}
+	}
+
+	_, err = tx.Exec(resetSeasonParticipantsQuery, startElo, r.seasonName, r.guildID)
+	if err != nil {
+		return errors.Wrap(err, "failed to reset season participants")
+	}
+	for _, participant := range seasonParticipants {
+		_, err = tx.Exec(
+			updateSeasonParticipantRatingQuery,
+			participant.Elo,
+			participant.GamesPlayed,
+			participant.SeasonName,
+			participant.PlayerID,
+			r.guildID,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to update season participant")
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+	return nil
+}
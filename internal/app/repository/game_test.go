@@ -17,8 +17,8 @@ func TestGame(t *testing.T) {
 		t.Parallel()
 		dbx := newMigratedSQLiteDB(t)
 		queryTimeout := 2 * time.Second
-		gameRepo := repository.NewGame(dbx, &queryTimeout, "First Fan Faction Season")
-		playerRepo := repository.NewPlayer(dbx, &queryTimeout)
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
 
 		// Create players
 		err := playerRepo.InsertPlayer("Player 1", "1")
@@ -88,8 +88,8 @@ func TestGame(t *testing.T) {
 		t.Parallel()
 		dbx := newMigratedSQLiteDB(t)
 		queryTimeout := 2 * time.Second
-		gameRepo := repository.NewGame(dbx, &queryTimeout, "First Fan Faction Season")
-		playerRepo := repository.NewPlayer(dbx, &queryTimeout)
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
 
 		// Create players
 		err := playerRepo.InsertPlayer("Player 1", "1")
@@ -138,8 +138,8 @@ func TestGame(t *testing.T) {
 		t.Parallel()
 		dbx := newMigratedSQLiteDB(t)
 		queryTimeout := 2 * time.Second
-		gameRepo := repository.NewGame(dbx, &queryTimeout, "Second Fan Faction Season")
-		playerRepo := repository.NewPlayer(dbx, &queryTimeout)
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "Second Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
 
 		// Create players
 		err := playerRepo.InsertPlayer("Player 1", "1")
@@ -190,8 +190,8 @@ func TestGame(t *testing.T) {
 		t.Parallel()
 		dbx := newMigratedSQLiteDB(t)
 		queryTimeout := 2 * time.Second
-		gameRepo := repository.NewGame(dbx, &queryTimeout, "First Fan Faction Season")
-		playerRepo := repository.NewPlayer(dbx, &queryTimeout)
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
 
 		// Create players
 		err := playerRepo.InsertPlayer("Player 1", "1")
@@ -237,3 +237,170 @@ func TestGame(t *testing.T) {
 		assert.Contains(t, err.Error(), "game is already registered")
 	})
 }
+
+func TestGetSeasonGameParticipants(t *testing.T) {
+	t.Parallel()
+	t.Run("Test participants are returned in registration order", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+
+		err := playerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 2", "2")
+		require.NoError(t, err)
+
+		err = gameRepo.CreateGameWithParticipants("2", []*model.GameParticipant{
+			{PlayerID: 1, Score: 110, EloChange: 10, EloBefore: 1000},
+			{PlayerID: 2, Score: 100, EloChange: -10, EloBefore: 1000},
+		})
+		require.NoError(t, err)
+		err = gameRepo.CreateGameWithParticipants("1", []*model.GameParticipant{
+			{PlayerID: 2, Score: 120, EloChange: 12, EloBefore: 990},
+			{PlayerID: 1, Score: 90, EloChange: -12, EloBefore: 1010},
+		})
+		require.NoError(t, err)
+
+		participants, err := gameRepo.GetSeasonGameParticipants()
+		require.NoError(t, err)
+		assert.Len(t, participants, 4)
+		assert.Equal(t, "2", participants[0].GameID)
+		assert.Equal(t, 1, participants[0].PlayerID)
+		assert.Equal(t, "2", participants[1].GameID)
+		assert.Equal(t, 2, participants[1].PlayerID)
+		assert.Equal(t, "1", participants[2].GameID)
+		assert.Equal(t, 2, participants[2].PlayerID)
+		assert.Equal(t, 12, participants[2].EloChange)
+		assert.Equal(t, "1", participants[3].GameID)
+		assert.Equal(t, 1, participants[3].PlayerID)
+	})
+}
+
+func TestGetAllGameParticipants(t *testing.T) {
+	t.Parallel()
+	t.Run("Test participants of every season are returned", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		firstGameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		secondGameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "Second Fan Faction Season")
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+
+		err := seasonRepo.CreateSeason(&model.SeasonRules{
+			SeasonName:                "Second Fan Faction Season",
+			KFactor:                   64,
+			StartElo:                  1000,
+			MinPlayers:                2,
+			MaxGameAgeDays:            60,
+			AllowedFanFactionSettings: "On - with Fire & Ice",
+		})
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 2", "2")
+		require.NoError(t, err)
+
+		err = firstGameRepo.CreateGameWithParticipants("1", []*model.GameParticipant{
+			{PlayerID: 1, Score: 110, EloChange: 10, EloBefore: 1000},
+			{PlayerID: 2, Score: 100, EloChange: -10, EloBefore: 1000},
+		})
+		require.NoError(t, err)
+		err = secondGameRepo.CreateGameWithParticipants("2", []*model.GameParticipant{
+			{PlayerID: 1, Score: 90, EloChange: -10, EloBefore: 1000},
+			{PlayerID: 2, Score: 120, EloChange: 10, EloBefore: 1000},
+		})
+		require.NoError(t, err)
+
+		seasonParticipants, err := secondGameRepo.GetSeasonGameParticipants()
+		require.NoError(t, err)
+		assert.Len(t, seasonParticipants, 2)
+
+		participants, err := secondGameRepo.GetAllGameParticipants()
+		require.NoError(t, err)
+		assert.Len(t, participants, 4)
+		assert.Equal(t, "1", participants[0].GameID)
+		assert.Equal(t, "First Fan Faction Season", participants[0].SeasonName)
+		assert.Equal(t, "2", participants[3].GameID)
+		assert.Equal(t, "Second Fan Faction Season", participants[3].SeasonName)
+
+		rules, err := seasonRepo.GetAllRules()
+		require.NoError(t, err)
+		assert.Len(t, rules, 2)
+	})
+}
+
+func TestVoidGame(t *testing.T) {
+	t.Parallel()
+	t.Run("Test voided games are no longer returned", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+
+		err := playerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 2", "2")
+		require.NoError(t, err)
+		for _, gameID := range []string{"1", "2"} {
+			err = gameRepo.CreateGameWithParticipants(gameID, []*model.GameParticipant{
+				{PlayerID: 1, Score: 110, EloChange: 10, EloBefore: 1000},
+				{PlayerID: 2, Score: 100, EloChange: -10, EloBefore: 1000},
+			})
+			require.NoError(t, err)
+			for playerID, eloChange := range map[int]int{1: 10, 2: -10} {
+				_, err = seasonRepo.UpsertSeasonParticipant(playerID, eloChange)
+				require.NoError(t, err)
+			}
+		}
+		participants, err := gameRepo.GetSeasonGameParticipants()
+		require.NoError(t, err)
+		require.Len(t, participants, 4)
+
+		err = gameRepo.VoidGame(
+			"1",
+			"moderator-1",
+			"Cheating",
+			1000,
+			participants[2:],
+			[]*model.SeasonParticipant{{SeasonName: "First Fan Faction Season", PlayerID: 1, Elo: 1010, GamesPlayed: 1}},
+		)
+		require.NoError(t, err)
+		err = gameRepo.VoidGame("1", "moderator-1", "Cheating", 1000, nil, nil)
+		require.ErrorIs(t, err, repository.ErrGameNotFound)
+
+		participants, err = gameRepo.GetSeasonGameParticipants()
+		require.NoError(t, err)
+		require.Len(t, participants, 2)
+		assert.Equal(t, "2", participants[0].GameID)
+		participants, err = gameRepo.GetAllGameParticipants()
+		require.NoError(t, err)
+		assert.Len(t, participants, 2)
+		games, err := gameRepo.GetHeadToHeadGames(1, 2)
+		require.NoError(t, err)
+		assert.Len(t, games, 1)
+		otherGuildGameRepo := repository.NewGame(dbx, &queryTimeout, "guild-2", "First Fan Faction Season")
+		games, err = otherGuildGameRepo.GetHeadToHeadGames(1, 2)
+		require.NoError(t, err)
+		assert.Empty(t, games)
+
+		game, err := gameRepo.GetGameWithParticipants("1")
+		require.NoError(t, err)
+		assert.NotNil(t, game.VoidedAt)
+		assert.Equal(t, "Cheating", game.VoidReason)
+
+		// Participants without games start over
+		seasonParticipant, err := seasonRepo.GetSeasonParticipant(1)
+		require.NoError(t, err)
+		assert.Equal(t, 1010, seasonParticipant.Elo)
+		assert.Equal(t, 1, seasonParticipant.GamesPlayed)
+		seasonParticipant, err = seasonRepo.GetSeasonParticipant(2)
+		require.NoError(t, err)
+		assert.Equal(t, 1000, seasonParticipant.Elo)
+		assert.Equal(t, 0, seasonParticipant.GamesPlayed)
+	})
+}
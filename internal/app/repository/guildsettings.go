@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"database/sql"
+	"log"
+	"time"
+	"tmff-discord-app/internal/app/repository/model"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrGuildSettingsNotFound = errors.New("guild settings don't exist")
+)
+
+const (
+	getGuildSettingsQuery = `
+		SELECT 
+			guild_id, 
+			games_channel_id, 
+			leaderboard_channel_id, 
+			registered_players_channel_id, 
+			staff_channel_id, 
+			moderator_role_ids, 
+			rate_limit_minutes, 
+			public_results, 
+			current_season, 
+			updated_at 
+		FROM 
+			guild_settings 
+		WHERE 
+			guild_id = $1`
+	upsertGuildSettingsQuery = `
+		INSERT INTO guild_settings (
+			guild_id, 
+			games_channel_id, 
+			leaderboard_channel_id, 
+			registered_players_channel_id, 
+			staff_channel_id, 
+			moderator_role_ids, 
+			rate_limit_minutes, 
+			public_results, 
+			current_season
+		) 
+		VALUES (
+			:guild_id, 
+			:games_channel_id, 
+			:leaderboard_channel_id, 
+			:registered_players_channel_id, 
+			:staff_channel_id, 
+			:moderator_role_ids, 
+			:rate_limit_minutes, 
+			:public_results, 
+			:current_season
+		) 
+		ON CONFLICT(guild_id) DO UPDATE SET 
+			games_channel_id = excluded.games_channel_id, 
+			leaderboard_channel_id = excluded.leaderboard_channel_id, 
+			registered_players_channel_id = excluded.registered_players_channel_id, 
+			staff_channel_id = excluded.staff_channel_id, 
+			moderator_role_ids = excluded.moderator_role_ids, 
+			rate_limit_minutes = excluded.rate_limit_minutes, 
+			public_results = excluded.public_results, 
+			current_season = excluded.current_season, 
+			updated_at = CURRENT_TIMESTAMP`
+
+	// Rows created before leagues were scoped by guild have an empty guild ID. The foreign keys of the season and game
+	// tables cascade, their rows are updated on their own as well for connections without foreign key enforcement.
+	assignUnscopedPlayersQuery              = `UPDATE players SET guild_id = $1 WHERE guild_id = ''`
+	assignUnscopedAccountsQuery             = `UPDATE player_accounts SET guild_id = $1 WHERE guild_id = ''`
+	assignUnscopedLinkRequestsQuery         = `UPDATE player_link_requests SET guild_id = $1 WHERE guild_id = ''`
+	assignUnscopedRegistrationRequestsQuery = `UPDATE registration_requests SET guild_id = $1 WHERE guild_id = ''`
+	assignUnscopedSeasonsQuery              = `UPDATE seasons SET guild_id = $1 WHERE guild_id = ''`
+	assignUnscopedGamesQuery                = `UPDATE games SET guild_id = $1 WHERE guild_id = ''`
+	assignUnscopedSeasonRulesQuery          = `UPDATE season_rules SET guild_id = $1 WHERE guild_id = ''`
+	assignUnscopedSeasonParticipantsQuery   = `UPDATE season_participants SET guild_id = $1 WHERE guild_id = ''`
+	assignUnscopedGameParticipantsQuery     = `UPDATE game_participants SET guild_id = $1 WHERE guild_id = ''`
+	initCurrentSeasonQuery                  = `
+		INSERT INTO guild_settings (guild_id, current_season) 
+		VALUES ($1, $2) 
+		ON CONFLICT(guild_id) DO UPDATE SET current_season = excluded.current_season 
+		WHERE guild_settings.current_season = ''`
+)
+
+type GuildSettings struct {
+	db           *sqlx.DB
+	queryTimeout *time.Duration
+}
+
+func NewGuildSettings(db *sqlx.DB, queryTimeout *time.Duration) *GuildSettings {
+	return &GuildSettings{
+		db:           db,
+		queryTimeout: queryTimeout,
+	}
+}
+
+func (r *GuildSettings) Get(guildID string) (*model.GuildSettings, error) {
+	var settings model.GuildSettings
+	err := r.db.Get(&settings, getGuildSettingsQuery, guildID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrGuildSettingsNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query guild settings")
+	}
+	return &settings, nil
+}
+
+// Upsert stores the settings of the guild, replacing the previous settings.
+func (r *GuildSettings) Upsert(settings *model.GuildSettings) error {
+	_, err := r.db.NamedExec(upsertGuildSettingsQuery, settings)
+	if err != nil {
+		return errors.Wrap(err, "failed to store guild settings")
+	}
+	return nil
+}
+
+// AssignUnscopedData assigns the league that was kept before leagues were scoped by guild to the guild. The current
+// season is only set if the guild doesn't have one yet.
+func (r *GuildSettings) AssignUnscopedData(guildID, currentSeason string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer func(tx *sqlx.Tx) {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil {
+			log.Printf("failed to rollback transaction: %v", rollbackErr)
+		}
+	}(tx)
+
+	for _, query := range []string{
+		assignUnscopedPlayersQuery,
+		assignUnscopedAccountsQuery,
+		assignUnscopedLinkRequestsQuery,
+		assignUnscopedRegistrationRequestsQuery,
+		assignUnscopedSeasonsQuery,
+		assignUnscopedGamesQuery,
+		assignUnscopedSeasonRulesQuery,
+		assignUnscopedSeasonParticipantsQuery,
+		assignUnscopedGameParticipantsQuery,
+	} {
+		_, err = tx.Exec(query, guildID)
+		if err != nil {
+			return errors.Wrap(err, "failed to assign data to guild")
+		}
+	}
+	if currentSeason != "" {
+		_, err = tx.Exec(initCurrentSeasonQuery, guildID, currentSeason)
+		if err != nil {
+			return errors.Wrap(err, "failed to set current season")
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+	return nil
+}
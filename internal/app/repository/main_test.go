@@ -4,15 +4,35 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 	"tmff-discord-app/internal/app/config"
 	"tmff-discord-app/internal/app/db"
+	"tmff-discord-app/internal/app/repository"
+	"tmff-discord-app/internal/app/repository/model"
 )
 
+// testGuildID is the guild that owns the league created by the migrations, its first season is rated by the rules it
+// was configured with before the rules were stored per season.
+const testGuildID = "guild-1"
+
 func newMigratedSQLiteDB(t *testing.T) *sqlx.DB {
 	conf := &config.Config{
 		DBFile: ":memory:",
 	}
 	dbx, err := db.SetupDatabase(conf)
 	require.NoError(t, err)
+	queryTimeout := 2 * time.Second
+	err = repository.NewGuildSettings(dbx, &queryTimeout).AssignUnscopedData(testGuildID, "First Fan Faction Season")
+	require.NoError(t, err)
+	seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+	err = seasonRepo.InsertMissingRules(&model.SeasonRules{
+		KFactor:                   64,
+		StartElo:                  1000,
+		EloFloor:                  0,
+		MinPlayers:                2,
+		MaxGameAgeDays:            60,
+		AllowedFanFactionSettings: "On - with Fire & Ice,On - no Fire & Ice",
+	})
+	require.NoError(t, err)
 	return dbx
 }
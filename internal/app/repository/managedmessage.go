@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"time"
+	"tmff-discord-app/internal/app/repository/model"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+const (
+	getManagedMessagesQuery = `
+		SELECT guild_id, name, page, channel_id, message_id, updated_at 
+		FROM managed_messages 
+		WHERE guild_id = $1 AND name = $2 
+		ORDER BY page ASC`
+	upsertManagedMessageQuery = `
+		INSERT INTO managed_messages (guild_id, name, page, channel_id, message_id) 
+		VALUES (:guild_id, :name, :page, :channel_id, :message_id) 
+		ON CONFLICT(guild_id, name, page) DO UPDATE SET 
+			channel_id = excluded.channel_id, 
+			message_id = excluded.message_id, 
+			updated_at = CURRENT_TIMESTAMP`
+	deleteManagedMessagesFromQuery = `DELETE FROM managed_messages WHERE guild_id = $1 AND name = $2 AND page >= $3`
+)
+
+type ManagedMessage struct {
+	db           *sqlx.DB
+	queryTimeout *time.Duration
+}
+
+func NewManagedMessage(db *sqlx.DB, queryTimeout *time.Duration) *ManagedMessage {
+	return &ManagedMessage{
+		db:           db,
+		queryTimeout: queryTimeout,
+	}
+}
+
+// GetMessages returns the pages of the message in the guild in page order.
+func (r *ManagedMessage) GetMessages(guildID, name string) ([]*model.ManagedMessage, error) {
+	var messages []*model.ManagedMessage
+	err := r.db.Select(&messages, getManagedMessagesQuery, guildID, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query managed messages")
+	}
+	return messages, nil
+}
+
+func (r *ManagedMessage) Upsert(message *model.ManagedMessage) error {
+	_, err := r.db.NamedExec(upsertManagedMessageQuery, message)
+	if err != nil {
+		return errors.Wrap(err, "failed to upsert managed message")
+	}
+	return nil
+}
+
+// DeleteFrom forgets the pages of the message starting at the page.
+func (r *ManagedMessage) DeleteFrom(guildID, name string, page int) error {
+	_, err := r.db.Exec(deleteManagedMessagesFromQuery, guildID, name, page)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete managed messages")
+	}
+	return nil
+}
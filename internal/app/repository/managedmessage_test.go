@@ -0,0 +1,72 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+	"tmff-discord-app/internal/app/repository"
+	"tmff-discord-app/internal/app/repository/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagedMessage(t *testing.T) {
+	t.Parallel()
+	t.Run("Test pages are tracked per guild", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		managedMessageRepo := repository.NewManagedMessage(dbx, &queryTimeout)
+
+		for page, messageID := range []string{"message-1", "message-2", "message-3"} {
+			err := managedMessageRepo.Upsert(&model.ManagedMessage{
+				GuildID:   testGuildID,
+				Name:      "leaderboard",
+				Page:      page,
+				ChannelID: "channel-1",
+				MessageID: messageID,
+			})
+			require.NoError(t, err)
+		}
+		err := managedMessageRepo.Upsert(&model.ManagedMessage{
+			GuildID:   "guild-2",
+			Name:      "leaderboard",
+			Page:      0,
+			ChannelID: "channel-2",
+			MessageID: "message-4",
+		})
+		require.NoError(t, err)
+
+		messages, err := managedMessageRepo.GetMessages(testGuildID, "leaderboard")
+		require.NoError(t, err)
+		require.Len(t, messages, 3)
+		assert.Equal(t, "message-1", messages[0].MessageID)
+		assert.Equal(t, 2, messages[2].Page)
+
+		// The table shrunk to one page
+		err = managedMessageRepo.DeleteFrom(testGuildID, "leaderboard", 1)
+		require.NoError(t, err)
+		messages, err = managedMessageRepo.GetMessages(testGuildID, "leaderboard")
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+
+		err = managedMessageRepo.Upsert(&model.ManagedMessage{
+			GuildID:   testGuildID,
+			Name:      "leaderboard",
+			Page:      0,
+			ChannelID: "channel-3",
+			MessageID: "message-5",
+		})
+		require.NoError(t, err)
+		messages, err = managedMessageRepo.GetMessages(testGuildID, "leaderboard")
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, "channel-3", messages[0].ChannelID)
+		assert.Equal(t, "message-5", messages[0].MessageID)
+
+		messages, err = managedMessageRepo.GetMessages("guild-2", "leaderboard")
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, "message-4", messages[0].MessageID)
+	})
+}
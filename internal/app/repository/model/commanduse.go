@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+type CommandUse struct {
+	ID      int       `db:"id"`
+	GuildID string    `db:"guild_id"`
+	UserID  string    `db:"user_id"`
+	Command string    `db:"command"`
+	UsedAt  time.Time `db:"used_at"`
+}
@@ -5,22 +5,38 @@ import "time"
 type GameWithParticipants struct {
 	GameID       string
 	SeasonName   string
+	VoidedAt     *time.Time
+	VoidReason   string
 	CreatedAt    time.Time
 	Participants []GameParticipant
 }
 
 type Game struct {
-	BGAID      string    `db:"bga_id"`
+	BGAID      string     `db:"bga_id"`
+	SeasonName string     `db:"season_name"`
+	VoidedAt   *time.Time `db:"voided_at"`
+	VoidReason string     `db:"void_reason"`
+	CreatedAt  time.Time  `db:"created_at"`
+}
+
+type GameParticipant struct {
+	ID         int       `db:"id"`
+	GameID     string    `db:"game_id"`
 	SeasonName string    `db:"season_name"`
+	PlayerID   int       `db:"player_id"`
+	Score      int       `db:"score"`
+	EloChange  int       `db:"elo_change"`
+	EloBefore  int       `db:"elo_before"`
 	CreatedAt  time.Time `db:"created_at"`
 }
 
-type GameParticipant struct {
-	ID        int       `db:"id"`
-	GameID    string    `db:"game_id"`
-	PlayerID  int       `db:"player_id"`
-	Score     int       `db:"score"`
-	EloChange int       `db:"elo_change"`
-	EloBefore int       `db:"elo_before"`
-	CreatedAt time.Time `db:"created_at"`
+// HeadToHeadGame is a game two players both played in.
+type HeadToHeadGame struct {
+	GameID     string    `db:"game_id"`
+	SeasonName string    `db:"season_name"`
+	ScoreA     int       `db:"score_a"`
+	ScoreB     int       `db:"score_b"`
+	EloBeforeA int       `db:"elo_before_a"`
+	EloBeforeB int       `db:"elo_before_b"`
+	CreatedAt  time.Time `db:"created_at"`
 }
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+type GuildSettings struct {
+	GuildID                    string    `db:"guild_id"`
+	GamesChannelID             string    `db:"games_channel_id"`
+	LeaderboardChannelID       string    `db:"leaderboard_channel_id"`
+	RegisteredPlayersChannelID string    `db:"registered_players_channel_id"`
+	StaffChannelID             string    `db:"staff_channel_id"`
+	ModeratorRoleIDs           string    `db:"moderator_role_ids"`
+	RateLimitMinutes           int       `db:"rate_limit_minutes"`
+	PublicResults              bool      `db:"public_results"`
+	CurrentSeason              string    `db:"current_season"`
+	UpdatedAt                  time.Time `db:"updated_at"`
+}
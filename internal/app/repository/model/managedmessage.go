@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+type ManagedMessage struct {
+	GuildID   string    `db:"guild_id"`
+	Name      string    `db:"name"`
+	Page      int       `db:"page"`
+	ChannelID string    `db:"channel_id"`
+	MessageID string    `db:"message_id"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
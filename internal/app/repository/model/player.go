@@ -6,5 +6,38 @@ type Player struct {
 	ID        int       `db:"id"`
 	Name      string    `db:"name"`
 	BGAID     string    `db:"bga_id"`
+	DiscordID *string   `db:"discord_id"`
+	Active    bool      `db:"active"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type PlayerLinkRequest struct {
+	DiscordID string    `db:"discord_id"`
+	PlayerID  int       `db:"player_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+const (
+	RegistrationPending  = "pending"
+	RegistrationApproved = "approved"
+	RegistrationRejected = "rejected"
+)
+
+type RegistrationRequest struct {
+	ID         int       `db:"id"`
+	DiscordID  string    `db:"discord_id"`
+	Name       string    `db:"name"`
+	BGAID      string    `db:"bga_id"`
+	Status     string    `db:"status"`
+	ReviewedBy *string   `db:"reviewed_by"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// PlayerAccount is an additional BGA account of a player.
+type PlayerAccount struct {
+	ID        int       `db:"id"`
+	PlayerID  int       `db:"player_id"`
+	Name      string    `db:"name"`
+	BGAID     string    `db:"bga_id"`
 	CreatedAt time.Time `db:"created_at"`
 }
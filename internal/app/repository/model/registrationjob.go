@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type RegistrationJob struct {
+	ID               int       `db:"id"`
+	GuildID          string    `db:"guild_id"`
+	GameLink         string    `db:"game_link"`
+	UserID           string    `db:"user_id"`
+	ChannelID        string    `db:"channel_id"`
+	AppID            string    `db:"app_id"`
+	InteractionToken string    `db:"interaction_token"`
+	Status           string    `db:"status"`
+	Attempts         int       `db:"attempts"`
+	Error            string    `db:"error"`
+	CreatedAt        time.Time `db:"created_at"`
+	UpdatedAt        time.Time `db:"updated_at"`
+}
@@ -2,13 +2,33 @@ package model
 
 import "time"
 
-const StartElo = 1000
+type Season struct {
+	Name           string     `db:"name"`
+	CreatedAt      time.Time  `db:"created_at"`
+	EndedAt        *time.Time `db:"ended_at"`
+	MinGames       int        `db:"min_games"`
+	SignUpRequired bool       `db:"sign_up_required"`
+}
+
+type SeasonRules struct {
+	GuildID                   string    `db:"guild_id"`
+	SeasonName                string    `db:"season_name"`
+	KFactor                   int       `db:"k_factor"`
+	StartElo                  int       `db:"start_elo"`
+	EloFloor                  int       `db:"elo_floor"`
+	MinPlayers                int       `db:"min_players"`
+	MaxGameAgeDays            int       `db:"max_game_age_days"`
+	AllowedFanFactionSettings string    `db:"allowed_fan_faction_settings"`
+	CreatedAt                 time.Time `db:"created_at"`
+}
 
 type SeasonParticipant struct {
 	ID          int       `db:"id"`
+	GuildID     string    `db:"guild_id"`
 	SeasonName  string    `db:"season_name"`
 	PlayerID    int       `db:"player_id"`
 	Elo         int       `db:"elo"`
 	GamesPlayed int       `db:"games_played"`
+	FinalRank   *int      `db:"final_rank"`
 	CreatedAt   time.Time `db:"created_at"`
 }
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"database/sql"
+	"log"
+	"strings"
 	"time"
 	"tmff-discord-app/internal/app/repository/model"
 
@@ -11,30 +13,172 @@ import (
 )
 
 var (
-	ErrPlayerNotFound = errors.New("player doesn't exist")
+	ErrPlayerNotFound       = errors.New("player doesn't exist")
+	ErrPlayerAlreadyLinked  = errors.New("player is already linked to a Discord account")
+	ErrDiscordAlreadyLinked = errors.New("this Discord account is already linked to a player")
+	ErrLinkRequestNotFound  = errors.New("no pending link request for this Discord account")
+
+	ErrRegistrationRequestNotFound = errors.New("registration request doesn't exist")
+	ErrRegistrationAlreadyReviewed = errors.New("registration request has already been reviewed")
+	ErrRegistrationAlreadyPending  = errors.New("you already have a pending registration request")
+
+	ErrPlayerAlreadyExists = errors.New("a player with this name or BGA ID already exists")
+	ErrPlayersInSameGame   = errors.New("players can't be merged, they played in the same game")
+	ErrAccountNotFound     = errors.New("account doesn't exist")
 )
 
 const (
-	getPlayerQuery     = `SELECT id, name, bga_id, created_at FROM players WHERE name = $1`
-	insertPlayerQuery  = `INSERT INTO players (name, bga_id) VALUES ($1, $2)`
-	getAllPlayersQuery = `SELECT id, name, bga_id, created_at FROM players ORDER BY name ASC`
+	getPlayerQuery = `
+		SELECT id, name, bga_id, discord_id, active, created_at 
+		FROM players 
+		WHERE name_key = normalize_name($1) AND guild_id = $2`
+	getPlayerByDiscordIDQuery = `
+		SELECT id, name, bga_id, discord_id, active, created_at 
+		FROM players 
+		WHERE discord_id = $1 AND guild_id = $2`
+	getPlayerByIDQuery = `SELECT id, name, bga_id, discord_id, active, created_at FROM players WHERE id = $1`
+	insertPlayerQuery  = `
+		INSERT INTO players (name, name_key, bga_id, guild_id) 
+		VALUES ($1, normalize_name($1), $2, $3)`
+	getAllPlayersQuery = `
+		SELECT id, name, bga_id, discord_id, active, created_at 
+		FROM players 
+		WHERE guild_id = $1 
+		ORDER BY name ASC`
+	getActivePlayersQuery = `
+		SELECT id, name, bga_id, discord_id, active, created_at 
+		FROM players 
+		WHERE active AND guild_id = $1 
+		ORDER BY name ASC`
+	renamePlayerQuery = `
+		UPDATE players 
+		SET name = $1, name_key = normalize_name($1) 
+		WHERE name_key = normalize_name($2) AND guild_id = $3`
+	setBGAIDQuery = `
+		UPDATE players 
+		SET bga_id = $1 
+		WHERE name_key = normalize_name($2) AND guild_id = $3`
+	deactivatePlayerQuery = `
+		UPDATE players 
+		SET active = FALSE 
+		WHERE name_key = normalize_name($1) AND guild_id = $2`
+	linkPlayerQuery        = `UPDATE players SET discord_id = $1 WHERE id = $2 AND discord_id IS NULL`
+	upsertLinkRequestQuery = `
+		INSERT INTO player_link_requests (discord_id, player_id, guild_id) 
+		VALUES ($1, $2, $3) 
+		ON CONFLICT(guild_id, discord_id) DO UPDATE SET player_id = excluded.player_id, created_at = CURRENT_TIMESTAMP`
+	getLinkRequestQuery = `
+		SELECT discord_id, player_id, created_at 
+		FROM player_link_requests 
+		WHERE discord_id = $1 AND guild_id = $2`
+	deleteLinkRequestQuery = `DELETE FROM player_link_requests WHERE discord_id = $1 AND guild_id = $2`
+
+	insertRegistrationRequestQuery = `
+		INSERT INTO registration_requests (discord_id, name, bga_id, guild_id) 
+		VALUES ($1, $2, $3, $4)`
+	getRegistrationRequestQuery = `
+		SELECT id, discord_id, name, bga_id, status, reviewed_by, created_at 
+		FROM registration_requests 
+		WHERE id = $1 AND guild_id = $2`
+	reviewRegistrationRequestQuery = `
+		UPDATE registration_requests 
+		SET status = $1, reviewed_by = $2 
+		WHERE id = $3 AND guild_id = $4 AND status = 'pending'`
+	deleteRegistrationRequestQuery = `
+		DELETE FROM registration_requests 
+		WHERE id = $1 AND guild_id = $2 AND status = 'pending'`
+
+	mergeGameParticipantsQuery    = `UPDATE game_participants SET player_id = $1 WHERE player_id = $2 AND guild_id = $3`
+	updateGameParticipantEloQuery = `
+		UPDATE game_participants 
+		SET elo_before = $1, elo_change = $2 
+		WHERE id = $3`
+	// Season participations of the source are moved unless the target already takes part in that season
+	mergeSeasonParticipantsQuery = `
+		UPDATE season_participants 
+		SET player_id = $1 
+		WHERE player_id = $2 
+			AND season_name NOT IN (SELECT season_name FROM season_participants WHERE player_id = $1)`
+	deleteSeasonParticipantsQuery      = `DELETE FROM season_participants WHERE player_id = $1`
+	getPlayerSeasonsQuery              = `SELECT season_name FROM season_participants WHERE player_id = $1`
+	setSeasonParticipantFinalRankQuery = `UPDATE season_participants SET final_rank = $1 WHERE id = $2`
+	updateSeasonParticipantRatingQuery = `
+		UPDATE season_participants 
+		SET elo = $1, games_played = $2 
+		WHERE season_name = $3 AND player_id = $4 AND guild_id = $5`
+	deletePlayerLinkRequestsQuery = `DELETE FROM player_link_requests WHERE player_id = $1`
+	deletePlayerQuery             = `DELETE FROM players WHERE id = $1`
+	moveDiscordIDQuery            = `UPDATE players SET discord_id = $1 WHERE id = $2 AND discord_id IS NULL`
+	moveAccountsQuery             = `UPDATE player_accounts SET player_id = $1 WHERE player_id = $2`
+
+	getPlayerByAccountNameQuery = `
+		SELECT p.id, p.name, p.bga_id, p.discord_id, p.active, p.created_at 
+		FROM players p 
+			LEFT JOIN player_accounts a ON a.player_id = p.id 
+		WHERE (p.name_key = normalize_name($1) OR a.name_key = normalize_name($1)) AND p.guild_id = $2 
+		LIMIT 1`
+	countAccountsQuery = `
+		SELECT COUNT(*) 
+		FROM player_accounts 
+		WHERE (name_key = normalize_name($1) OR bga_id = $2) AND guild_id = $3`
+	countPlayersQuery = `
+		SELECT COUNT(*) 
+		FROM players 
+		WHERE (name_key = normalize_name($1) OR bga_id = $2) AND guild_id = $3`
+	insertAccountQuery = `
+		INSERT INTO player_accounts (player_id, name, name_key, bga_id, guild_id) 
+		VALUES ($1, $2, normalize_name($2), $3, $4)`
+	getAccountsQuery = `
+		SELECT id, player_id, name, bga_id, created_at 
+		FROM player_accounts 
+		WHERE player_id = $1 AND guild_id = $2 
+		ORDER BY name ASC`
+	deleteAccountQuery = `DELETE FROM player_accounts WHERE name_key = normalize_name($1) AND guild_id = $2`
+
+	// The pseudonym is derived from the player's ID so it stays the same every time it is shown
+	anonymizePlayerQuery = `
+		UPDATE players 
+		SET 
+			name = 'Anonymous Player ' || id, 
+			name_key = normalize_name('Anonymous Player ' || id), 
+			bga_id = 'anonymous-' || id, 
+			discord_id = NULL, 
+			active = FALSE 
+		WHERE id = $1`
+	deletePlayerAccountsQuery             = `DELETE FROM player_accounts WHERE player_id = $1`
+	deleteDiscordLinkRequestsQuery        = `DELETE FROM player_link_requests WHERE discord_id = $1 AND guild_id = $2`
+	deletePlayerRegistrationRequestsQuery = `
+		DELETE FROM registration_requests 
+		WHERE (discord_id = $1 OR normalize_name(name) = normalize_name($2) OR bga_id = $3) AND guild_id = $4`
 )
 
 type Player struct {
 	db           *sqlx.DB
 	queryTimeout *time.Duration
+	guildID      string
 }
 
-func NewPlayer(db *sqlx.DB, queryTimeout *time.Duration) *Player {
+// NewPlayer returns the players of the league of the guild.
+func NewPlayer(db *sqlx.DB, queryTimeout *time.Duration, guildID string) *Player {
 	return &Player{
 		db:           db,
 		queryTimeout: queryTimeout,
+		guildID:      guildID,
 	}
 }
 
 func (p *Player) GetPlayer(name string) (*model.Player, error) {
 	var player model.Player
-	err := p.db.Get(&player, getPlayerQuery, name)
+	err := p.db.Get(&player, getPlayerQuery, name, p.guildID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPlayerNotFound
+	}
+	return &player, err
+}
+
+func (p *Player) GetPlayerByDiscordID(discordID string) (*model.Player, error) {
+	var player model.Player
+	err := p.db.Get(&player, getPlayerByDiscordIDQuery, discordID, p.guildID)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, ErrPlayerNotFound
 	}
@@ -43,7 +187,17 @@ func (p *Player) GetPlayer(name string) (*model.Player, error) {
 
 func (p *Player) GetPlayers() ([]*model.Player, error) {
 	var players []*model.Player
-	err := p.db.Select(&players, getAllPlayersQuery)
+	err := p.db.Select(&players, getAllPlayersQuery, p.guildID)
+	if err != nil {
+		return nil, err
+	}
+	return players, nil
+}
+
+// GetActivePlayers returns the players that have not been deactivated.
+func (p *Player) GetActivePlayers() ([]*model.Player, error) {
+	var players []*model.Player
+	err := p.db.Select(&players, getActivePlayersQuery, p.guildID)
 	if err != nil {
 		return nil, err
 	}
@@ -51,6 +205,543 @@ func (p *Player) GetPlayers() ([]*model.Player, error) {
 }
 
 func (p *Player) InsertPlayer(name, bgaID string) error {
-	_, err := p.db.Exec(insertPlayerQuery, name, bgaID)
+	_, err := p.insertPlayer(p.db, name, bgaID)
+	return err
+}
+
+// insertPlayer inserts the player unless the name or BGA ID is taken by a player or an account, it returns the ID of
+// the new player.
+func (p *Player) insertPlayer(e sqlx.Ext, name, bgaID string) (int, error) {
+	err := checkNotAnAccount(e, p.guildID, name, bgaID)
+	if err != nil {
+		return 0, err
+	}
+	result, err := e.Exec(insertPlayerQuery, name, bgaID, p.guildID)
+	if err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed") {
+		return 0, errors.Wrap(ErrPlayerAlreadyExists, err.Error())
+	}
+	if err != nil {
+		return 0, err
+	}
+	playerID, err := result.LastInsertId()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get player ID")
+	}
+	return int(playerID), nil
+}
+
+// GetPlayerByAccountName returns the player that plays under the BGA name, either as the player's name or as one of
+// the player's additional accounts.
+func (p *Player) GetPlayerByAccountName(name string) (*model.Player, error) {
+	var player model.Player
+	err := p.db.Get(&player, getPlayerByAccountNameQuery, name, p.guildID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPlayerNotFound
+	}
+	return &player, err
+}
+
+// AddAccount adds an additional BGA account to the player.
+func (p *Player) AddAccount(playerID int, name, bgaID string) error {
+	var count int
+	err := p.db.Get(&count, countPlayersQuery, name, bgaID, p.guildID)
+	if err != nil {
+		return errors.Wrap(err, "failed to check players")
+	}
+	if count > 0 {
+		return ErrPlayerAlreadyExists
+	}
+
+	_, err = p.db.Exec(insertAccountQuery, playerID, name, bgaID, p.guildID)
+	switch {
+	case err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed"):
+		return ErrPlayerAlreadyExists
+	case err != nil && strings.Contains(err.Error(), "FOREIGN KEY constraint failed"):
+		return ErrPlayerNotFound
+	case err != nil:
+		return errors.Wrap(err, "failed to insert account")
+	}
+	return nil
+}
+
+func (p *Player) GetAccounts(playerID int) ([]*model.PlayerAccount, error) {
+	var accounts []*model.PlayerAccount
+	err := p.db.Select(&accounts, getAccountsQuery, playerID, p.guildID)
+	if err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+func (p *Player) RemoveAccount(name string) error {
+	result, err := p.db.Exec(deleteAccountQuery, name, p.guildID)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete account")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to get affected rows")
+	}
+	if rowsAffected == 0 {
+		return ErrAccountNotFound
+	}
+	return nil
+}
+
+// checkNotAnAccount makes sure the name and BGA ID are not used by an additional account of another player.
+func checkNotAnAccount(q sqlx.Queryer, guildID, name, bgaID string) error {
+	var count int
+	err := sqlx.Get(q, &count, countAccountsQuery, name, bgaID, guildID)
+	if err != nil {
+		return errors.Wrap(err, "failed to check accounts")
+	}
+	if count > 0 {
+		return ErrPlayerAlreadyExists
+	}
+	return nil
+}
+
+// RequestLink stores a pending link between a Discord account and a player, a new request replaces the previous one.
+func (p *Player) RequestLink(playerID int, discordID string) error {
+	_, err := p.db.Exec(upsertLinkRequestQuery, discordID, playerID, p.guildID)
+	if err != nil && strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+		return ErrPlayerNotFound
+	}
 	return err
 }
+
+func (p *Player) GetLinkRequest(discordID string) (*model.PlayerLinkRequest, error) {
+	var request model.PlayerLinkRequest
+	err := p.db.Get(&request, getLinkRequestQuery, discordID, p.guildID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrLinkRequestNotFound
+	}
+	return &request, err
+}
+
+// ApproveLink links the player of the pending request to the Discord account and removes the request.
+func (p *Player) ApproveLink(discordID string) (*model.Player, error) {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer func(tx *sqlx.Tx) {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil {
+			log.Printf("failed to rollback transaction: %v", rollbackErr)
+		}
+	}(tx)
+
+	var request model.PlayerLinkRequest
+	err = tx.Get(&request, getLinkRequestQuery, discordID, p.guildID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrLinkRequestNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get link request")
+	}
+
+	result, err := tx.Exec(linkPlayerQuery, discordID, request.PlayerID)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return nil, ErrDiscordAlreadyLinked
+		}
+		return nil, errors.Wrap(err, "failed to link player")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get affected rows")
+	}
+	if rowsAffected == 0 {
+		return nil, ErrPlayerAlreadyLinked
+	}
+
+	_, err = tx.Exec(deleteLinkRequestQuery, discordID, p.guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to delete link request")
+	}
+
+	var player model.Player
+	err = tx.Get(&player, getPlayerByIDQuery, request.PlayerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get linked player")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+	return &player, nil
+}
+
+// CreateRegistrationRequest stores a pending registration, a Discord account can only have one pending registration.
+func (p *Player) CreateRegistrationRequest(name, bgaID, discordID string) (*model.RegistrationRequest, error) {
+	result, err := p.db.Exec(insertRegistrationRequestQuery, discordID, name, bgaID, p.guildID)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return nil, ErrRegistrationAlreadyPending
+		}
+		return nil, errors.Wrap(err, "failed to insert registration request")
+	}
+	requestID, err := result.LastInsertId()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get registration request ID")
+	}
+	return p.GetRegistrationRequest(int(requestID))
+}
+
+func (p *Player) GetRegistrationRequest(requestID int) (*model.RegistrationRequest, error) {
+	var request model.RegistrationRequest
+	err := p.db.Get(&request, getRegistrationRequestQuery, requestID, p.guildID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrRegistrationRequestNotFound
+	}
+	return &request, err
+}
+
+// ApproveRegistrationRequest inserts the requested player linked to the Discord account that registered it.
+func (p *Player) ApproveRegistrationRequest(requestID int, reviewerID string) (*model.Player, error) {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer func(tx *sqlx.Tx) {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil {
+			log.Printf("failed to rollback transaction: %v", rollbackErr)
+		}
+	}(tx)
+
+	var request model.RegistrationRequest
+	err = tx.Get(&request, getRegistrationRequestQuery, requestID, p.guildID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrRegistrationRequestNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get registration request")
+	}
+	err = p.reviewRegistrationRequest(tx, requestID, model.RegistrationApproved, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	playerID, err := p.insertPlayer(tx, request.Name, request.BGAID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.Exec(linkPlayerQuery, request.DiscordID, playerID)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return nil, ErrDiscordAlreadyLinked
+		}
+		return nil, errors.Wrap(err, "failed to link player")
+	}
+
+	var player model.Player
+	err = tx.Get(&player, getPlayerByIDQuery, playerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get registered player")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+	return &player, nil
+}
+
+// DeleteRegistrationRequest removes a pending registration, so it can be requested again.
+func (p *Player) DeleteRegistrationRequest(requestID int) error {
+	_, err := p.db.Exec(deleteRegistrationRequestQuery, requestID, p.guildID)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete registration request")
+	}
+	return nil
+}
+
+func (p *Player) RejectRegistrationRequest(requestID int, reviewerID string) (*model.RegistrationRequest, error) {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer func(tx *sqlx.Tx) {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil {
+			log.Printf("failed to rollback transaction: %v", rollbackErr)
+		}
+	}(tx)
+
+	err = p.reviewRegistrationRequest(tx, requestID, model.RegistrationRejected, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	var request model.RegistrationRequest
+	err = tx.Get(&request, getRegistrationRequestQuery, requestID, p.guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get registration request")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+	return &request, nil
+}
+
+func (p *Player) reviewRegistrationRequest(tx *sqlx.Tx, requestID int, status, reviewerID string) error {
+	result, err := tx.Exec(reviewRegistrationRequestQuery, status, reviewerID, requestID, p.guildID)
+	if err != nil {
+		return errors.Wrap(err, "failed to review registration request")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to get affected rows")
+	}
+	if rowsAffected > 0 {
+		return nil
+	}
+
+	var request model.RegistrationRequest
+	err = tx.Get(&request, getRegistrationRequestQuery, requestID, p.guildID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrRegistrationRequestNotFound
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to get registration request")
+	}
+	return ErrRegistrationAlreadyReviewed
+}
+
+func (p *Player) RenamePlayer(name, newName string) error {
+	err := checkNotAnAccount(p.db, p.guildID, newName, "")
+	if err != nil {
+		return err
+	}
+	return p.updatePlayer(renamePlayerQuery, newName, name, p.guildID)
+}
+
+func (p *Player) SetBGAID(name, bgaID string) error {
+	err := checkNotAnAccount(p.db, p.guildID, "", bgaID)
+	if err != nil {
+		return err
+	}
+	return p.updatePlayer(setBGAIDQuery, bgaID, name, p.guildID)
+}
+
+// DeactivatePlayer retires the player, the player's history is kept but new games are no longer rated.
+func (p *Player) DeactivatePlayer(name string) error {
+	return p.updatePlayer(deactivatePlayerQuery, name, p.guildID)
+}
+
+func (p *Player) updatePlayer(query string, args ...any) error {
+	result, err := p.db.Exec(query, args...)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return ErrPlayerAlreadyExists
+		}
+		return errors.Wrap(err, "failed to update player")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to get affected rows")
+	}
+	if rowsAffected == 0 {
+		return ErrPlayerNotFound
+	}
+	return nil
+}
+
+// MergePlayers moves the games and seasons of the source player to the target player and keeps the source as an
+// additional account of the target. The recalculated ratings of the affected games and season participants are
+// stored in the same transaction.
+func (p *Player) MergePlayers(
+	source, target *model.Player,
+	gameParticipants []*model.GameParticipant,
+	seasonParticipants []*model.SeasonParticipant,
+) error {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer func(tx *sqlx.Tx) {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil {
+			log.Printf("failed to rollback transaction: %v", rollbackErr)
+		}
+	}(tx)
+
+	var seasonNames []string
+	err = tx.Select(&seasonNames, getPlayerSeasonsQuery, source.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get seasons of player")
+	}
+
+	_, err = tx.Exec(mergeGameParticipantsQuery, target.ID, source.ID, p.guildID)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return ErrPlayersInSameGame
+		}
+		return errors.Wrap(err, "failed to move game participants")
+	}
+	for _, participant := range gameParticipants {
+		_, err = tx.Exec(updateGameParticipantEloQuery, participant.EloBefore, participant.EloChange, participant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to update game participant")
+		}
+	}
+
+	_, err = tx.Exec(mergeSeasonParticipantsQuery, target.ID, source.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to move season participants")
+	}
+	_, err = tx.Exec(deleteSeasonParticipantsQuery, source.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete season participants")
+	}
+	for _, participant := range seasonParticipants {
+		_, err = tx.Exec(
+			updateSeasonParticipantRatingQuery,
+			participant.Elo,
+			participant.GamesPlayed,
+			participant.SeasonName,
+			participant.PlayerID,
+			p.guildID,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to update season participant")
+		}
+	}
+	for _, seasonName := range seasonNames {
+		err = p.rankEndedSeason(tx, seasonName)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(moveAccountsQuery, target.ID, source.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to move accounts")
+	}
+	_, err = tx.Exec(deletePlayerLinkRequestsQuery, source.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete link requests")
+	}
+	_, err = tx.Exec(deletePlayerQuery, source.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete player")
+	}
+	// The source keeps resolving to the target, games played from its BGA account are rated for the target
+	_, err = tx.Exec(insertAccountQuery, target.ID, source.Name, source.BGAID, p.guildID)
+	if err != nil {
+		return errors.Wrap(err, "failed to add account")
+	}
+	if source.DiscordID != nil {
+		_, err = tx.Exec(moveDiscordIDQuery, *source.DiscordID, target.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to move Discord link")
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+// rankEndedSeason sets the final ranks of an ended season again from the current standings, the players that have
+// played the season's minimum number of games are ranked by Elo.
+func (p *Player) rankEndedSeason(tx *sqlx.Tx, seasonName string) error {
+	var season model.Season
+	err := tx.Get(&season, getSeasonQuery, seasonName, p.guildID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get season %s", seasonName)
+	}
+	if season.EndedAt == nil {
+		return nil
+	}
+
+	var participants []*model.SeasonParticipant
+	err = tx.Select(&participants, getAllSeasonParticipantsQuery, seasonName, p.guildID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get season participants")
+	}
+	rank := 0
+	for _, participant := range participants {
+		// Players that haven't qualified have no final rank
+		var finalRank sql.NullInt64
+		if participant.GamesPlayed >= season.MinGames {
+			rank++
+			finalRank = sql.NullInt64{Int64: int64(rank), Valid: true}
+		}
+		_, err = tx.Exec(setSeasonParticipantFinalRankQuery, finalRank, participant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to set final rank")
+		}
+	}
+	return nil
+}
+
+// AnonymizePlayer replaces the name and BGA ID of the player with a pseudonym and removes every other personal data
+// the player left behind: the Discord link, additional accounts, link and registration requests. The player's games
+// and season participations are kept so the ratings of other players don't change.
+func (p *Player) AnonymizePlayer(name string) (*model.Player, error) {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer func(tx *sqlx.Tx) {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil {
+			log.Printf("failed to rollback transaction: %v", rollbackErr)
+		}
+	}(tx)
+
+	var player model.Player
+	err = tx.Get(&player, getPlayerQuery, name, p.guildID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPlayerNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get player")
+	}
+
+	var discordID string
+	if player.DiscordID != nil {
+		discordID = *player.DiscordID
+	}
+	_, err = tx.Exec(deletePlayerRegistrationRequestsQuery, discordID, player.Name, player.BGAID, p.guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to delete registration requests")
+	}
+	_, err = tx.Exec(deletePlayerLinkRequestsQuery, player.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to delete link requests")
+	}
+	_, err = tx.Exec(deleteDiscordLinkRequestsQuery, discordID, p.guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to delete link requests")
+	}
+	_, err = tx.Exec(deletePlayerAccountsQuery, player.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to delete accounts")
+	}
+	_, err = tx.Exec(anonymizePlayerQuery, player.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return nil, ErrPlayerAlreadyExists
+		}
+		return nil, errors.Wrap(err, "failed to anonymize player")
+	}
+
+	err = tx.Get(&player, getPlayerByIDQuery, player.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get anonymized player")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+	return &player, nil
+}
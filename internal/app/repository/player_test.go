@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 	"tmff-discord-app/internal/app/repository"
+	"tmff-discord-app/internal/app/repository/model"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -16,7 +17,7 @@ func TestPlayer(t *testing.T) {
 		t.Parallel()
 		dbx := newMigratedSQLiteDB(t)
 		queryTimeout := 2 * time.Second
-		playerRepo := repository.NewPlayer(dbx, &queryTimeout)
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
 
 		err := playerRepo.InsertPlayer("Test Player1", "1")
 		require.NoError(t, err)
@@ -30,7 +31,7 @@ func TestPlayer(t *testing.T) {
 		t.Parallel()
 		dbx := newMigratedSQLiteDB(t)
 		queryTimeout := 2 * time.Second
-		playerRepo := repository.NewPlayer(dbx, &queryTimeout)
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
 
 		err := playerRepo.InsertPlayer("Test Player1", "1")
 		require.NoError(t, err)
@@ -50,23 +51,354 @@ func TestPlayer(t *testing.T) {
 		t.Parallel()
 		dbx := newMigratedSQLiteDB(t)
 		queryTimeout := 2 * time.Second
-		playerRepo := repository.NewPlayer(dbx, &queryTimeout)
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
 
 		err := playerRepo.InsertPlayer("Test Player1", "1")
 		require.NoError(t, err)
 		err = playerRepo.InsertPlayer("Test Player1", "1")
 		require.Error(t, err)
-		assert.Contains(t, err.Error(), "UNIQUE constraint failed: players.name")
+		assert.True(t, errors.Is(err, repository.ErrPlayerAlreadyExists))
 	})
 
 	t.Run("Test get doesn't exist", func(t *testing.T) {
 		t.Parallel()
 		dbx := newMigratedSQLiteDB(t)
 		queryTimeout := 2 * time.Second
-		playerRepo := repository.NewPlayer(dbx, &queryTimeout)
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
 
 		_, err := playerRepo.GetPlayer("Test Player1")
 		require.Error(t, err)
 		assert.True(t, errors.Is(err, repository.ErrPlayerNotFound))
 	})
 }
+
+func TestPlayerLink(t *testing.T) {
+	t.Parallel()
+	t.Run("Test approve link", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+
+		err := playerRepo.InsertPlayer("Test Player1", "1")
+		require.NoError(t, err)
+		player, err := playerRepo.GetPlayer("Test Player1")
+		require.NoError(t, err)
+		assert.Nil(t, player.DiscordID)
+
+		err = playerRepo.RequestLink(player.ID, "discord-1")
+		require.NoError(t, err)
+		request, err := playerRepo.GetLinkRequest("discord-1")
+		require.NoError(t, err)
+		assert.Equal(t, player.ID, request.PlayerID)
+
+		linkedPlayer, err := playerRepo.ApproveLink("discord-1")
+		require.NoError(t, err)
+		require.NotNil(t, linkedPlayer.DiscordID)
+		assert.Equal(t, "discord-1", *linkedPlayer.DiscordID)
+
+		player, err = playerRepo.GetPlayerByDiscordID("discord-1")
+		require.NoError(t, err)
+		assert.Equal(t, "Test Player1", player.Name)
+
+		_, err = playerRepo.GetLinkRequest("discord-1")
+		assert.True(t, errors.Is(err, repository.ErrLinkRequestNotFound))
+	})
+
+	t.Run("Test approve without request", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+
+		_, err := playerRepo.ApproveLink("discord-1")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, repository.ErrLinkRequestNotFound))
+	})
+
+	t.Run("Test player already linked", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+
+		err := playerRepo.InsertPlayer("Test Player1", "1")
+		require.NoError(t, err)
+		player, err := playerRepo.GetPlayer("Test Player1")
+		require.NoError(t, err)
+
+		err = playerRepo.RequestLink(player.ID, "discord-1")
+		require.NoError(t, err)
+		err = playerRepo.RequestLink(player.ID, "discord-2")
+		require.NoError(t, err)
+		_, err = playerRepo.ApproveLink("discord-1")
+		require.NoError(t, err)
+
+		_, err = playerRepo.ApproveLink("discord-2")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, repository.ErrPlayerAlreadyLinked))
+	})
+
+	t.Run("Test request link for unknown player", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+
+		err := playerRepo.RequestLink(1, "discord-1")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, repository.ErrPlayerNotFound))
+	})
+}
+
+func TestRegistrationRequest(t *testing.T) {
+	t.Parallel()
+	t.Run("Test approve registration", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+
+		request, err := playerRepo.CreateRegistrationRequest("Test Player1", "1", "discord-1")
+		require.NoError(t, err)
+		assert.Equal(t, model.RegistrationPending, request.Status)
+
+		player, err := playerRepo.ApproveRegistrationRequest(request.ID, "moderator")
+		require.NoError(t, err)
+		assert.Equal(t, "Test Player1", player.Name)
+		assert.Equal(t, "1", player.BGAID)
+		require.NotNil(t, player.DiscordID)
+		assert.Equal(t, "discord-1", *player.DiscordID)
+
+		request, err = playerRepo.GetRegistrationRequest(request.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.RegistrationApproved, request.Status)
+		require.NotNil(t, request.ReviewedBy)
+		assert.Equal(t, "moderator", *request.ReviewedBy)
+
+		_, err = playerRepo.RejectRegistrationRequest(request.ID, "moderator")
+		assert.True(t, errors.Is(err, repository.ErrRegistrationAlreadyReviewed))
+	})
+
+	t.Run("Test reject registration", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+
+		request, err := playerRepo.CreateRegistrationRequest("Test Player1", "1", "discord-1")
+		require.NoError(t, err)
+		_, err = playerRepo.CreateRegistrationRequest("Test Player1", "1", "discord-1")
+		assert.True(t, errors.Is(err, repository.ErrRegistrationAlreadyPending))
+
+		request, err = playerRepo.RejectRegistrationRequest(request.ID, "moderator")
+		require.NoError(t, err)
+		assert.Equal(t, model.RegistrationRejected, request.Status)
+
+		_, err = playerRepo.GetPlayer("Test Player1")
+		assert.True(t, errors.Is(err, repository.ErrPlayerNotFound))
+		_, err = playerRepo.ApproveRegistrationRequest(request.ID, "moderator")
+		assert.True(t, errors.Is(err, repository.ErrRegistrationAlreadyReviewed))
+
+		// A rejected registration can be requested again
+		_, err = playerRepo.CreateRegistrationRequest("Test Player1", "1", "discord-1")
+		require.NoError(t, err)
+	})
+
+	t.Run("Test approve registration of a taken name", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+
+		request, err := playerRepo.CreateRegistrationRequest("Test Player1", "1", "discord-1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("test player1", "2")
+		require.NoError(t, err)
+
+		// The name key check of a registered player applies to approved registrations as well
+		_, err = playerRepo.ApproveRegistrationRequest(request.ID, "moderator")
+		assert.True(t, errors.Is(err, repository.ErrPlayerAlreadyExists))
+		request, err = playerRepo.GetRegistrationRequest(request.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.RegistrationPending, request.Status)
+	})
+
+	t.Run("Test delete registration", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+
+		request, err := playerRepo.CreateRegistrationRequest("Test Player1", "1", "discord-1")
+		require.NoError(t, err)
+		err = playerRepo.DeleteRegistrationRequest(request.ID)
+		require.NoError(t, err)
+		_, err = playerRepo.GetRegistrationRequest(request.ID)
+		assert.True(t, errors.Is(err, repository.ErrRegistrationRequestNotFound))
+
+		_, err = playerRepo.CreateRegistrationRequest("Test Player1", "1", "discord-1")
+		require.NoError(t, err)
+	})
+
+	t.Run("Test review unknown registration", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+
+		_, err := playerRepo.ApproveRegistrationRequest(1, "moderator")
+		assert.True(t, errors.Is(err, repository.ErrRegistrationRequestNotFound))
+		_, err = playerRepo.RejectRegistrationRequest(1, "moderator")
+		assert.True(t, errors.Is(err, repository.ErrRegistrationRequestNotFound))
+	})
+}
+
+func TestPlayerMaintenance(t *testing.T) {
+	t.Parallel()
+	t.Run("Test rename and change BGA ID", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+
+		err := playerRepo.InsertPlayer("Test Playr1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Test Player2", "2")
+		require.NoError(t, err)
+
+		err = playerRepo.RenamePlayer("Test Playr1", "Test Player1")
+		require.NoError(t, err)
+		err = playerRepo.SetBGAID("Test Player1", "11")
+		require.NoError(t, err)
+		player, err := playerRepo.GetPlayer("Test Player1")
+		require.NoError(t, err)
+		assert.Equal(t, "11", player.BGAID)
+
+		err = playerRepo.RenamePlayer("Test Player1", "Test Player2")
+		assert.True(t, errors.Is(err, repository.ErrPlayerAlreadyExists))
+		err = playerRepo.SetBGAID("Test Player1", "2")
+		assert.True(t, errors.Is(err, repository.ErrPlayerAlreadyExists))
+		err = playerRepo.RenamePlayer("Test Player3", "Test Player4")
+		assert.True(t, errors.Is(err, repository.ErrPlayerNotFound))
+	})
+
+	t.Run("Test deactivate player", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+
+		err := playerRepo.InsertPlayer("Test Player1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Test Player2", "2")
+		require.NoError(t, err)
+
+		err = playerRepo.DeactivatePlayer("Test Player1")
+		require.NoError(t, err)
+		player, err := playerRepo.GetPlayer("Test Player1")
+		require.NoError(t, err)
+		assert.False(t, player.Active)
+
+		players, err := playerRepo.GetActivePlayers()
+		require.NoError(t, err)
+		assert.Len(t, players, 1)
+		assert.Equal(t, "Test Player2", players[0].Name)
+		players, err = playerRepo.GetPlayers()
+		require.NoError(t, err)
+		assert.Len(t, players, 2)
+
+		err = playerRepo.DeactivatePlayer("Test Player3")
+		assert.True(t, errors.Is(err, repository.ErrPlayerNotFound))
+	})
+}
+
+func TestPlayerAccounts(t *testing.T) {
+	t.Parallel()
+	t.Run("Test resolve additional account", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+
+		err := playerRepo.InsertPlayer("Test Player1", "1")
+		require.NoError(t, err)
+		player, err := playerRepo.GetPlayer("Test Player1")
+		require.NoError(t, err)
+
+		err = playerRepo.AddAccount(player.ID, "Test Player1 Alt", "11")
+		require.NoError(t, err)
+		accounts, err := playerRepo.GetAccounts(player.ID)
+		require.NoError(t, err)
+		require.Len(t, accounts, 1)
+		assert.Equal(t, "11", accounts[0].BGAID)
+
+		resolved, err := playerRepo.GetPlayerByAccountName("Test Player1 Alt")
+		require.NoError(t, err)
+		assert.Equal(t, player.ID, resolved.ID)
+		resolved, err = playerRepo.GetPlayerByAccountName("Test Player1")
+		require.NoError(t, err)
+		assert.Equal(t, player.ID, resolved.ID)
+
+		err = playerRepo.RemoveAccount("Test Player1 Alt")
+		require.NoError(t, err)
+		_, err = playerRepo.GetPlayerByAccountName("Test Player1 Alt")
+		assert.True(t, errors.Is(err, repository.ErrPlayerNotFound))
+		err = playerRepo.RemoveAccount("Test Player1 Alt")
+		assert.True(t, errors.Is(err, repository.ErrAccountNotFound))
+	})
+
+	t.Run("Test accounts and players can't share names or IDs", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+
+		err := playerRepo.InsertPlayer("Test Player1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Test Player2", "2")
+		require.NoError(t, err)
+		player, err := playerRepo.GetPlayer("Test Player1")
+		require.NoError(t, err)
+
+		err = playerRepo.AddAccount(player.ID, "Test Player2", "22")
+		assert.True(t, errors.Is(err, repository.ErrPlayerAlreadyExists))
+		err = playerRepo.AddAccount(player.ID, "Test Player1 Alt", "2")
+		assert.True(t, errors.Is(err, repository.ErrPlayerAlreadyExists))
+		err = playerRepo.AddAccount(player.ID, "Test Player1 Alt", "11")
+		require.NoError(t, err)
+		err = playerRepo.AddAccount(player.ID, "Test Player1 Alt", "12")
+		assert.True(t, errors.Is(err, repository.ErrPlayerAlreadyExists))
+
+		err = playerRepo.InsertPlayer("Test Player1 Alt", "3")
+		assert.True(t, errors.Is(err, repository.ErrPlayerAlreadyExists))
+		err = playerRepo.RenamePlayer("Test Player2", "Test Player1 Alt")
+		assert.True(t, errors.Is(err, repository.ErrPlayerAlreadyExists))
+		err = playerRepo.SetBGAID("Test Player2", "11")
+		assert.True(t, errors.Is(err, repository.ErrPlayerAlreadyExists))
+	})
+}
+
+func TestPlayerNameMatching(t *testing.T) {
+	t.Parallel()
+	dbx := newMigratedSQLiteDB(t)
+	queryTimeout := 2 * time.Second
+	playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+
+	err := playerRepo.InsertPlayer("Stahlbrötchen", "1")
+	require.NoError(t, err)
+	player, err := playerRepo.GetPlayer("STAHLBRO\u0308TCHEN")
+	require.NoError(t, err)
+	assert.Equal(t, "Stahlbrötchen", player.Name)
+
+	err = playerRepo.InsertPlayer("stahlbrötchen", "2")
+	require.Error(t, err)
+	err = playerRepo.AddAccount(player.ID, "STAHLBRÖTCHEN", "3")
+	assert.True(t, errors.Is(err, repository.ErrPlayerAlreadyExists))
+
+	err = playerRepo.AddAccount(player.ID, "Brötchen (alt)", "3")
+	require.NoError(t, err)
+	resolved, err := playerRepo.GetPlayerByAccountName("brötchen (ALT)")
+	require.NoError(t, err)
+	assert.Equal(t, player.ID, resolved.ID)
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+	"tmff-discord-app/internal/app/repository/model"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrRegistrationJobNotFound = errors.New("registration job doesn't exist")
+)
+
+const (
+	registrationJobColumns = `
+		id, 
+		guild_id, 
+		game_link, 
+		user_id, 
+		channel_id, 
+		app_id, 
+		interaction_token, 
+		status, 
+		attempts, 
+		error, 
+		created_at, 
+		updated_at`
+	insertRegistrationJobQuery = `
+		INSERT INTO registration_jobs (guild_id, game_link, user_id, channel_id, app_id, interaction_token, status) 
+		VALUES (:guild_id, :game_link, :user_id, :channel_id, :app_id, :interaction_token, :status)`
+	claimRegistrationJobQuery = `
+		UPDATE registration_jobs 
+		SET status = $1, attempts = attempts + 1, updated_at = CURRENT_TIMESTAMP 
+		WHERE id = (SELECT id FROM registration_jobs WHERE status = $2 ORDER BY id ASC LIMIT 1) 
+		RETURNING` + registrationJobColumns
+	finishRegistrationJobQuery = `
+		UPDATE registration_jobs 
+		SET status = $1, error = $2, interaction_token = '', updated_at = CURRENT_TIMESTAMP 
+		WHERE id = $3`
+	countRegistrationJobsBeforeQuery = `SELECT COUNT(*) FROM registration_jobs WHERE status = $1 AND id < $2`
+	selectRegistrationJobsQuery      = `
+		SELECT * FROM (
+			SELECT` + registrationJobColumns + ` 
+			FROM registration_jobs 
+			WHERE guild_id = $1 AND status = $2 
+			ORDER BY id DESC 
+			LIMIT $3
+		) 
+		ORDER BY id ASC`
+	requeueRegistrationJobQuery = `
+		UPDATE registration_jobs 
+		SET status = $1, error = '', app_id = $2, interaction_token = $3, updated_at = CURRENT_TIMESTAMP 
+		WHERE id = $4 AND guild_id = $5 AND status = $6 
+		RETURNING` + registrationJobColumns
+	resetRegistrationJobsQuery = `
+		UPDATE registration_jobs 
+		SET status = $1, updated_at = CURRENT_TIMESTAMP 
+		WHERE status = $2`
+)
+
+// RegistrationJob stores the games waiting to be registered, the jobs of every guild share one queue. Statuses are
+// given by the caller.
+type RegistrationJob struct {
+	db           *sqlx.DB
+	queryTimeout *time.Duration
+}
+
+func NewRegistrationJob(db *sqlx.DB, queryTimeout *time.Duration) *RegistrationJob {
+	return &RegistrationJob{
+		db:           db,
+		queryTimeout: queryTimeout,
+	}
+}
+
+// Insert stores the job and sets its ID.
+func (r *RegistrationJob) Insert(job *model.RegistrationJob) error {
+	result, err := r.db.NamedExec(insertRegistrationJobQuery, job)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert registration job")
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return errors.Wrap(err, "failed to get registration job ID")
+	}
+	job.ID = int(id)
+	return nil
+}
+
+// ClaimOldest moves the oldest job with the status to the claimed status and counts the attempt.
+func (r *RegistrationJob) ClaimOldest(status, claimedStatus string) (*model.RegistrationJob, error) {
+	var job model.RegistrationJob
+	err := r.db.Get(&job, claimRegistrationJobQuery, claimedStatus, status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRegistrationJobNotFound
+		}
+		return nil, errors.Wrap(err, "failed to claim registration job")
+	}
+	return &job, nil
+}
+
+// Finish sets the final status of the job and forgets its interaction token.
+func (r *RegistrationJob) Finish(id int, status, errorMessage string) error {
+	_, err := r.db.Exec(finishRegistrationJobQuery, status, errorMessage, id)
+	if err != nil {
+		return errors.Wrap(err, "failed to finish registration job")
+	}
+	return nil
+}
+
+// CountBefore returns the number of jobs with the status that are ahead of the job.
+func (r *RegistrationJob) CountBefore(id int, status string) (int, error) {
+	var count int
+	err := r.db.Get(&count, countRegistrationJobsBeforeQuery, status, id)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to count registration jobs")
+	}
+	return count, nil
+}
+
+// GetJobs returns the latest jobs of the guild with the status, oldest first.
+func (r *RegistrationJob) GetJobs(guildID, status string, limit int) ([]*model.RegistrationJob, error) {
+	var jobs []*model.RegistrationJob
+	err := r.db.Select(&jobs, selectRegistrationJobsQuery, guildID, status, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query registration jobs")
+	}
+	return jobs, nil
+}
+
+// Requeue moves the job of the guild from the status to the status of the given job, the job gets the interaction of
+// the given job.
+func (r *RegistrationJob) Requeue(job *model.RegistrationJob, fromStatus string) (*model.RegistrationJob, error) {
+	var requeued model.RegistrationJob
+	err := r.db.Get(
+		&requeued,
+		requeueRegistrationJobQuery,
+		job.Status,
+		job.AppID,
+		job.InteractionToken,
+		job.ID,
+		job.GuildID,
+		fromStatus,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRegistrationJobNotFound
+		}
+		return nil, errors.Wrap(err, "failed to requeue registration job")
+	}
+	return &requeued, nil
+}
+
+// ResetStatus moves every job with the status to the new status.
+func (r *RegistrationJob) ResetStatus(status, newStatus string) error {
+	_, err := r.db.Exec(resetRegistrationJobsQuery, newStatus, status)
+	if err != nil {
+		return errors.Wrap(err, "failed to reset registration jobs")
+	}
+	return nil
+}
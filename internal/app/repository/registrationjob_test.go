@@ -0,0 +1,137 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+	"tmff-discord-app/internal/app/repository"
+	"tmff-discord-app/internal/app/repository/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistrationJob(t *testing.T) {
+	t.Parallel()
+	newJob := func(guildID, gameLink string) *model.RegistrationJob {
+		return &model.RegistrationJob{
+			GuildID:          guildID,
+			GameLink:         gameLink,
+			UserID:           "user-1",
+			ChannelID:        "channel-1",
+			AppID:            "app-1",
+			InteractionToken: "token-1",
+			Status:           "queued",
+		}
+	}
+
+	t.Run("Test jobs are claimed in order", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		registrationJobRepo := repository.NewRegistrationJob(dbx, &queryTimeout)
+
+		first := newJob(testGuildID, "https://boardgamearena.com/table?table=1")
+		require.NoError(t, registrationJobRepo.Insert(first))
+		second := newJob("guild-2", "https://boardgamearena.com/table?table=2")
+		require.NoError(t, registrationJobRepo.Insert(second))
+		assert.Greater(t, second.ID, first.ID)
+
+		count, err := registrationJobRepo.CountBefore(second.ID, "queued")
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		claimed, err := registrationJobRepo.ClaimOldest("queued", "scraping")
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, claimed.ID)
+		assert.Equal(t, "scraping", claimed.Status)
+		assert.Equal(t, 1, claimed.Attempts)
+		assert.Equal(t, "token-1", claimed.InteractionToken)
+
+		claimed, err = registrationJobRepo.ClaimOldest("queued", "scraping")
+		require.NoError(t, err)
+		assert.Equal(t, second.ID, claimed.ID)
+
+		_, err = registrationJobRepo.ClaimOldest("queued", "scraping")
+		require.ErrorIs(t, err, repository.ErrRegistrationJobNotFound)
+	})
+
+	t.Run("Test finished jobs can be requeued", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		registrationJobRepo := repository.NewRegistrationJob(dbx, &queryTimeout)
+
+		job := newJob(testGuildID, "https://boardgamearena.com/table?table=1")
+		require.NoError(t, registrationJobRepo.Insert(job))
+		_, err := registrationJobRepo.ClaimOldest("queued", "scraping")
+		require.NoError(t, err)
+		require.NoError(t, registrationJobRepo.Finish(job.ID, "failed", "table does not exist"))
+
+		failed, err := registrationJobRepo.GetJobs(testGuildID, "failed", 10)
+		require.NoError(t, err)
+		require.Len(t, failed, 1)
+		assert.Equal(t, "table does not exist", failed[0].Error)
+		assert.Empty(t, failed[0].InteractionToken)
+
+		// Jobs of other guilds and jobs with another status are not requeued
+		retry := &model.RegistrationJob{
+			ID:               job.ID,
+			GuildID:          "guild-2",
+			AppID:            "app-1",
+			InteractionToken: "token-2",
+			Status:           "queued",
+		}
+		_, err = registrationJobRepo.Requeue(retry, "failed")
+		require.ErrorIs(t, err, repository.ErrRegistrationJobNotFound)
+		retry.GuildID = testGuildID
+		_, err = registrationJobRepo.Requeue(retry, "rated")
+		require.ErrorIs(t, err, repository.ErrRegistrationJobNotFound)
+
+		requeued, err := registrationJobRepo.Requeue(retry, "failed")
+		require.NoError(t, err)
+		assert.Equal(t, "queued", requeued.Status)
+		assert.Equal(t, "token-2", requeued.InteractionToken)
+		assert.Empty(t, requeued.Error)
+		assert.Equal(t, job.GameLink, requeued.GameLink)
+		assert.Equal(t, 1, requeued.Attempts)
+	})
+
+	t.Run("Test latest jobs of a guild", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		registrationJobRepo := repository.NewRegistrationJob(dbx, &queryTimeout)
+
+		var ids []int
+		for range 3 {
+			job := newJob(testGuildID, "https://boardgamearena.com/table?table=1")
+			require.NoError(t, registrationJobRepo.Insert(job))
+			ids = append(ids, job.ID)
+		}
+		require.NoError(t, registrationJobRepo.Insert(newJob("guild-2", "https://boardgamearena.com/table?table=2")))
+
+		jobs, err := registrationJobRepo.GetJobs(testGuildID, "queued", 2)
+		require.NoError(t, err)
+		require.Len(t, jobs, 2)
+		assert.Equal(t, ids[1], jobs[0].ID)
+		assert.Equal(t, ids[2], jobs[1].ID)
+	})
+
+	t.Run("Test reset status", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		registrationJobRepo := repository.NewRegistrationJob(dbx, &queryTimeout)
+
+		job := newJob(testGuildID, "https://boardgamearena.com/table?table=1")
+		require.NoError(t, registrationJobRepo.Insert(job))
+		_, err := registrationJobRepo.ClaimOldest("queued", "scraping")
+		require.NoError(t, err)
+
+		require.NoError(t, registrationJobRepo.ResetStatus("scraping", "queued"))
+		claimed, err := registrationJobRepo.ClaimOldest("queued", "scraping")
+		require.NoError(t, err)
+		assert.Equal(t, job.ID, claimed.ID)
+		assert.Equal(t, 2, claimed.Attempts)
+	})
+}
@@ -12,19 +12,130 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+var (
+	ErrSeasonNotFound      = errors.New("season does not exist")
+	ErrSeasonAlreadyEnded  = errors.New("season has already ended")
+	ErrSeasonAlreadyExists = errors.New("season already exists")
+	ErrAlreadyJoinedSeason = errors.New("player has already joined the season")
+
+	ErrSeasonParticipantNotFound = errors.New("player hasn't joined the season")
+)
+
 const (
+	getSeasonQuery = `
+		SELECT name, created_at, ended_at, min_games, sign_up_required 
+		FROM seasons 
+		WHERE name = $1 AND guild_id = $2`
+	setMinGamesQuery       = `UPDATE seasons SET min_games = $1 WHERE name = $2 AND guild_id = $3`
+	setSignUpRequiredQuery = `UPDATE seasons SET sign_up_required = $1 WHERE name = $2 AND guild_id = $3`
+	insertSeasonQuery      = `INSERT INTO seasons (name, guild_id) VALUES ($1, $2)`
+	getSeasonRulesQuery    = `
+		SELECT 
+			season_name, 
+			k_factor, 
+			start_elo, 
+			elo_floor, 
+			min_players, 
+			max_game_age_days, 
+			allowed_fan_faction_settings, 
+			created_at 
+		FROM 
+			season_rules 
+		WHERE 
+			season_name = $1 AND guild_id = $2`
+	getAllSeasonRulesQuery = `
+		SELECT 
+			season_name, 
+			k_factor, 
+			start_elo, 
+			elo_floor, 
+			min_players, 
+			max_game_age_days, 
+			allowed_fan_faction_settings, 
+			created_at 
+		FROM 
+			season_rules 
+		WHERE 
+			guild_id = $1 
+		ORDER BY 
+			created_at ASC`
+	insertSeasonRulesQuery = `
+		INSERT INTO season_rules (
+			guild_id, 
+			season_name, 
+			k_factor, 
+			start_elo, 
+			elo_floor, 
+			min_players, 
+			max_game_age_days, 
+			allowed_fan_faction_settings
+		) 
+		VALUES (
+			:guild_id, 
+			:season_name, 
+			:k_factor, 
+			:start_elo, 
+			:elo_floor, 
+			:min_players, 
+			:max_game_age_days, 
+			:allowed_fan_faction_settings
+		)`
+	getSeasonsWithoutRulesQuery = `
+		SELECT s.name 
+		FROM seasons s 
+		WHERE s.guild_id = $1 
+			AND NOT EXISTS (
+				SELECT 1 FROM season_rules r WHERE r.guild_id = s.guild_id AND r.season_name = s.name
+			) 
+		ORDER BY s.created_at ASC`
+	// The seeded rules keep the creation time of their season, so the rules of every season stay in order
+	insertMissingSeasonRulesQuery = `
+		INSERT INTO season_rules (
+			guild_id, 
+			season_name, 
+			k_factor, 
+			start_elo, 
+			elo_floor, 
+			min_players, 
+			max_game_age_days, 
+			allowed_fan_faction_settings, 
+			created_at
+		) 
+		SELECT s.guild_id, s.name, $1, $2, $3, $4, $5, $6, s.created_at 
+		FROM seasons s 
+		WHERE s.guild_id = $7 
+			AND NOT EXISTS (
+				SELECT 1 FROM season_rules r WHERE r.guild_id = s.guild_id AND r.season_name = s.name
+			)`
+	// A new season becomes the guild's current season if the guild has none or its current season has ended
+	startSeasonQuery = `
+		INSERT INTO guild_settings (guild_id, current_season) 
+		VALUES ($1, $2) 
+		ON CONFLICT(guild_id) DO UPDATE SET current_season = excluded.current_season, updated_at = CURRENT_TIMESTAMP 
+		WHERE guild_settings.current_season = '' 
+			OR guild_settings.current_season IN (
+				SELECT name FROM seasons WHERE guild_id = excluded.guild_id AND ended_at IS NOT NULL
+			)`
+	endSeasonQuery = `
+		UPDATE seasons 
+		SET ended_at = CURRENT_TIMESTAMP 
+		WHERE name = $1 AND guild_id = $2 AND ended_at IS NULL`
+	setFinalRankQuery = `
+		UPDATE season_participants 
+		SET final_rank = $1 
+		WHERE season_name = $2 AND player_id = $3 AND guild_id = $4`
 	getAllSeasonParticipantsQuery = `
-		SELECT id, season_name, player_id, elo, games_played, created_at 
+		SELECT id, season_name, player_id, elo, games_played, final_rank, created_at 
 		FROM season_participants 
-		WHERE season_name = $1 
+		WHERE season_name = $1 AND guild_id = $2 
 		ORDER BY elo DESC`
 	getSeasonParticipantQuery = `
 		SELECT id, season_name, player_id, elo, games_played, created_at 
 		FROM season_participants 
-		WHERE player_id = $1`
+		WHERE player_id = $1 AND season_name = $2 AND guild_id = $3`
 	insertSeasonParticipantQuery = `
-		INSERT INTO season_participants(season_name, player_id, elo, games_played) 
-		VALUES(:season_name,:player_id,:elo,:games_played)`
+		INSERT INTO season_participants(guild_id, season_name, player_id, elo, games_played) 
+		VALUES(:guild_id,:season_name,:player_id,:elo,:games_played)`
 	updateSeasonParticipantQuery = `
 		UPDATE season_participants 
 		SET elo =:elo, games_played =:games_played 
@@ -34,20 +145,223 @@ const (
 type Season struct {
 	db            *sqlx.DB
 	queryTimeout  *time.Duration
+	guildID       string
 	currentSeason string
 }
 
-func NewSeason(db *sqlx.DB, queryTimeout *time.Duration, currentSeason string) *Season {
+// NewSeason returns the seasons of the league of the guild, currentSeason is the season new games are registered in.
+func NewSeason(db *sqlx.DB, queryTimeout *time.Duration, guildID, currentSeason string) *Season {
 	return &Season{
 		db:            db,
 		queryTimeout:  queryTimeout,
+		guildID:       guildID,
 		currentSeason: currentSeason,
 	}
 }
 
+func (s *Season) GetCurrentSeason() (*model.Season, error) {
+	var season model.Season
+	err := s.db.Get(&season, getSeasonQuery, s.currentSeason, s.guildID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSeasonNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &season, nil
+}
+
+func (s *Season) GetRules() (*model.SeasonRules, error) {
+	var rules model.SeasonRules
+	err := s.db.Get(&rules, getSeasonRulesQuery, s.currentSeason, s.guildID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSeasonNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rules, nil
+}
+
+// GetAllRules returns the rules of every season.
+func (s *Season) GetAllRules() ([]*model.SeasonRules, error) {
+	var rules []*model.SeasonRules
+	err := s.db.Select(&rules, getAllSeasonRulesQuery, s.guildID)
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// GetSeasonsWithoutRules returns the names of the seasons that were created before the rules were stored per season.
+func (s *Season) GetSeasonsWithoutRules() ([]string, error) {
+	var names []string
+	err := s.db.Select(&names, getSeasonsWithoutRulesQuery, s.guildID)
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// InsertMissingRules stores the rules for every season that has no rules yet, the season name of the rules is ignored.
+func (s *Season) InsertMissingRules(rules *model.SeasonRules) error {
+	_, err := s.db.Exec(
+		insertMissingSeasonRulesQuery,
+		rules.KFactor,
+		rules.StartElo,
+		rules.EloFloor,
+		rules.MinPlayers,
+		rules.MaxGameAgeDays,
+		rules.AllowedFanFactionSettings,
+		s.guildID,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert season rules")
+	}
+	return nil
+}
+
+// CreateSeason creates a new season together with the rules it is rated by. The season becomes the guild's current
+// season if the previous season has ended.
+func (s *Season) CreateSeason(rules *model.SeasonRules) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer func(tx *sqlx.Tx) {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil {
+			log.Printf("failed to rollback transaction: %v", rollbackErr)
+		}
+	}(tx)
+
+	_, err = tx.Exec(insertSeasonQuery, rules.SeasonName, s.guildID)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return ErrSeasonAlreadyExists
+		}
+		return errors.Wrap(err, "failed to insert season")
+	}
+	seasonRules := *rules
+	seasonRules.GuildID = s.guildID
+	_, err = tx.NamedExec(insertSeasonRulesQuery, seasonRules)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert season rules")
+	}
+	_, err = tx.Exec(startSeasonQuery, s.guildID, rules.SeasonName)
+	if err != nil {
+		return errors.Wrap(err, "failed to start season")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+func (s *Season) SetMinGames(minGames int) error {
+	result, err := s.db.Exec(setMinGamesQuery, minGames, s.currentSeason, s.guildID)
+	if err != nil {
+		return errors.Wrap(err, "failed to set minimum games")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to get affected rows")
+	}
+	if rowsAffected == 0 {
+		return ErrSeasonNotFound
+	}
+	return nil
+}
+
+func (s *Season) SetSignUpRequired(signUpRequired bool) error {
+	result, err := s.db.Exec(setSignUpRequiredQuery, signUpRequired, s.currentSeason, s.guildID)
+	if err != nil {
+		return errors.Wrap(err, "failed to set sign up required")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to get affected rows")
+	}
+	if rowsAffected == 0 {
+		return ErrSeasonNotFound
+	}
+	return nil
+}
+
+// JoinSeason signs the player up for the current season at the season's start elo, before any games are played.
+func (s *Season) JoinSeason(playerID int) (*model.SeasonParticipant, error) {
+	var rules model.SeasonRules
+	err := s.db.Get(&rules, getSeasonRulesQuery, s.currentSeason, s.guildID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSeasonNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	participant := model.SeasonParticipant{
+		GuildID:     s.guildID,
+		SeasonName:  s.currentSeason,
+		PlayerID:    playerID,
+		Elo:         rules.StartElo,
+		GamesPlayed: 0,
+	}
+	_, err = s.db.NamedExec(insertSeasonParticipantQuery, participant)
+	switch {
+	case err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed"):
+		return nil, ErrAlreadyJoinedSeason
+	case err != nil && strings.Contains(err.Error(), "FOREIGN KEY constraint failed"):
+		return nil, errors.New("player or season does not exist")
+	case err != nil:
+		return nil, err
+	}
+	return &participant, nil
+}
+
+// EndSeason marks the current season as ended and archives the final rank of each player.
+func (s *Season) EndSeason(finalRanks map[int]int) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer func(tx *sqlx.Tx) {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil {
+			log.Printf("failed to rollback transaction: %v", rollbackErr)
+		}
+	}(tx)
+
+	result, err := tx.Exec(endSeasonQuery, s.currentSeason, s.guildID)
+	if err != nil {
+		return errors.Wrap(err, "failed to end season")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to get affected rows")
+	}
+	if rowsAffected == 0 {
+		return ErrSeasonAlreadyEnded
+	}
+
+	for playerID, finalRank := range finalRanks {
+		_, err = tx.Exec(setFinalRankQuery, finalRank, s.currentSeason, playerID, s.guildID)
+		if err != nil {
+			return errors.Wrap(err, "failed to set final rank")
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+	return nil
+}
+
 func (s *Season) GetAll() ([]*model.SeasonParticipant, error) {
 	var participants []*model.SeasonParticipant
-	err := s.db.Select(&participants, getAllSeasonParticipantsQuery, s.currentSeason)
+	err := s.db.Select(&participants, getAllSeasonParticipantsQuery, s.currentSeason, s.guildID)
 	if err != nil {
 		return nil, err
 	}
@@ -55,6 +369,18 @@ func (s *Season) GetAll() ([]*model.SeasonParticipant, error) {
 	return participants, nil
 }
 
+func (s *Season) GetSeasonParticipant(playerID int) (*model.SeasonParticipant, error) {
+	var participant model.SeasonParticipant
+	err := s.db.Get(&participant, getSeasonParticipantQuery, playerID, s.currentSeason, s.guildID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSeasonParticipantNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &participant, nil
+}
+
 func (s *Season) UpsertSeasonParticipant(playerID int, eloChange int) (*model.SeasonParticipant, error) {
 	tx, err := s.db.Beginx()
 	if err != nil {
@@ -67,15 +393,24 @@ func (s *Season) UpsertSeasonParticipant(playerID int, eloChange int) (*model.Se
 		}
 	}(tx)
 
+	var rules model.SeasonRules
+	err = tx.Get(&rules, getSeasonRulesQuery, s.currentSeason, s.guildID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("player or season does not exist")
+	} else if err != nil {
+		return nil, err
+	}
+
 	// Get the current season participant
 	var participant model.SeasonParticipant
-	err = tx.Get(&participant, getSeasonParticipantQuery, playerID)
+	err = tx.Get(&participant, getSeasonParticipantQuery, playerID, s.currentSeason, s.guildID)
 	if errors.Is(err, sql.ErrNoRows) {
 		// Create the participant
 		participant = model.SeasonParticipant{
+			GuildID:     s.guildID,
 			SeasonName:  s.currentSeason,
 			PlayerID:    playerID,
-			Elo:         model.StartElo + eloChange,
+			Elo:         max(rules.StartElo+eloChange, rules.EloFloor),
 			GamesPlayed: 1,
 		}
 		_, err = tx.NamedExec(insertSeasonParticipantQuery, participant)
@@ -96,9 +431,9 @@ func (s *Season) UpsertSeasonParticipant(playerID int, eloChange int) (*model.Se
 	}
 
 	// Update the participant
-	// Don't go below 0 elo
-	if participant.Elo+eloChange < 0 {
-		eloChange = -participant.Elo
+	// Don't go below the season's elo floor
+	if eloChange < 0 && participant.Elo+eloChange < rules.EloFloor {
+		eloChange = min(rules.EloFloor-participant.Elo, 0)
 	}
 	participant.Elo += eloChange
 	participant.GamesPlayed++
@@ -4,7 +4,9 @@ import (
 	"testing"
 	"time"
 	"tmff-discord-app/internal/app/repository"
+	"tmff-discord-app/internal/app/repository/model"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -15,8 +17,8 @@ func TestGetAll(t *testing.T) {
 		t.Parallel()
 		dbx := newMigratedSQLiteDB(t)
 		queryTimeout := 2 * time.Second
-		seasonRepo := repository.NewSeason(dbx, &queryTimeout, "First Fan Faction Season")
-		playerRepo := repository.NewPlayer(dbx, &queryTimeout)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
 
 		err := playerRepo.InsertPlayer("Test Player1", "1")
 		require.NoError(t, err)
@@ -55,8 +57,8 @@ func TestUpsert(t *testing.T) {
 		t.Parallel()
 		dbx := newMigratedSQLiteDB(t)
 		queryTimeout := 2 * time.Second
-		seasonRepo := repository.NewSeason(dbx, &queryTimeout, "First Fan Faction Season")
-		playerRepo := repository.NewPlayer(dbx, &queryTimeout)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
 
 		err := playerRepo.InsertPlayer("Test Player1", "1")
 		require.NoError(t, err)
@@ -68,8 +70,8 @@ func TestUpsert(t *testing.T) {
 		t.Parallel()
 		dbx := newMigratedSQLiteDB(t)
 		queryTimeout := 2 * time.Second
-		seasonRepo := repository.NewSeason(dbx, &queryTimeout, "First Fan Faction Season")
-		playerRepo := repository.NewPlayer(dbx, &queryTimeout)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
 
 		err := playerRepo.InsertPlayer("Test Player1", "1")
 		require.NoError(t, err)
@@ -92,8 +94,8 @@ func TestUpsert(t *testing.T) {
 		t.Parallel()
 		dbx := newMigratedSQLiteDB(t)
 		queryTimeout := 2 * time.Second
-		seasonRepo := repository.NewSeason(dbx, &queryTimeout, "First Fan Faction Season")
-		playerRepo := repository.NewPlayer(dbx, &queryTimeout)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
 
 		err := playerRepo.InsertPlayer("Test Player1", "1")
 		require.NoError(t, err)
@@ -113,7 +115,7 @@ func TestUpsert(t *testing.T) {
 		t.Parallel()
 		dbx := newMigratedSQLiteDB(t)
 		queryTimeout := 2 * time.Second
-		seasonRepo := repository.NewSeason(dbx, &queryTimeout, "First Fan Faction Season")
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
 
 		_, err := seasonRepo.UpsertSeasonParticipant(1, 1)
 		require.Error(t, err)
@@ -124,8 +126,8 @@ func TestUpsert(t *testing.T) {
 		t.Parallel()
 		dbx := newMigratedSQLiteDB(t)
 		queryTimeout := 2 * time.Second
-		seasonRepo := repository.NewSeason(dbx, &queryTimeout, "Second Fan Faction Season")
-		playerRepo := repository.NewPlayer(dbx, &queryTimeout)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "Second Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
 
 		err := playerRepo.InsertPlayer("Test Player1", "1")
 		require.NoError(t, err)
@@ -134,3 +136,221 @@ func TestUpsert(t *testing.T) {
 		assert.Contains(t, err.Error(), "player or season does not exist")
 	})
 }
+
+func TestEndSeason(t *testing.T) {
+	t.Parallel()
+	t.Run("Test end season archives final ranks", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+
+		err := playerRepo.InsertPlayer("Test Player1", "1")
+		require.NoError(t, err)
+		_, err = seasonRepo.UpsertSeasonParticipant(1, 10)
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Test Player2", "2")
+		require.NoError(t, err)
+		_, err = seasonRepo.UpsertSeasonParticipant(2, -10)
+		require.NoError(t, err)
+
+		season, err := seasonRepo.GetCurrentSeason()
+		require.NoError(t, err)
+		assert.Equal(t, "First Fan Faction Season", season.Name)
+		assert.Nil(t, season.EndedAt)
+
+		err = seasonRepo.EndSeason(map[int]int{1: 1, 2: 2})
+		require.NoError(t, err)
+
+		season, err = seasonRepo.GetCurrentSeason()
+		require.NoError(t, err)
+		assert.NotNil(t, season.EndedAt)
+
+		result, err := seasonRepo.GetAll()
+		require.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, 1, *result[0].FinalRank)
+		assert.Equal(t, 2, *result[1].FinalRank)
+	})
+
+	t.Run("Test end season twice", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+
+		err := seasonRepo.EndSeason(map[int]int{})
+		require.NoError(t, err)
+		err = seasonRepo.EndSeason(map[int]int{})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, repository.ErrSeasonAlreadyEnded))
+	})
+
+	t.Run("Test set minimum games", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+
+		season, err := seasonRepo.GetCurrentSeason()
+		require.NoError(t, err)
+		assert.Equal(t, 0, season.MinGames)
+
+		err = seasonRepo.SetMinGames(5)
+		require.NoError(t, err)
+
+		season, err = seasonRepo.GetCurrentSeason()
+		require.NoError(t, err)
+		assert.Equal(t, 5, season.MinGames)
+	})
+
+	t.Run("Test get season that doesn't exist", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "Second Fan Faction Season")
+
+		_, err := seasonRepo.GetCurrentSeason()
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, repository.ErrSeasonNotFound))
+	})
+}
+
+func TestSeasonRules(t *testing.T) {
+	t.Parallel()
+	t.Run("Test first season rules", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+
+		rules, err := seasonRepo.GetRules()
+		require.NoError(t, err)
+		assert.Equal(t, "First Fan Faction Season", rules.SeasonName)
+		assert.Equal(t, 64, rules.KFactor)
+		assert.Equal(t, 1000, rules.StartElo)
+		assert.Equal(t, 0, rules.EloFloor)
+		assert.Equal(t, 2, rules.MinPlayers)
+		assert.Equal(t, 60, rules.MaxGameAgeDays)
+		assert.Equal(t, "On - with Fire & Ice,On - no Fire & Ice", rules.AllowedFanFactionSettings)
+	})
+
+	t.Run("Test create season applies its rules", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		firstSeasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		secondSeasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "Second Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+
+		err := firstSeasonRepo.CreateSeason(&model.SeasonRules{
+			SeasonName:                "Second Fan Faction Season",
+			KFactor:                   32,
+			StartElo:                  1500,
+			EloFloor:                  1400,
+			MinPlayers:                3,
+			MaxGameAgeDays:            30,
+			AllowedFanFactionSettings: "On - no Fire & Ice",
+		})
+		require.NoError(t, err)
+
+		rules, err := secondSeasonRepo.GetRules()
+		require.NoError(t, err)
+		assert.Equal(t, 32, rules.KFactor)
+		assert.Equal(t, 1500, rules.StartElo)
+
+		err = playerRepo.InsertPlayer("Test Player1", "1")
+		require.NoError(t, err)
+		_, err = firstSeasonRepo.UpsertSeasonParticipant(1, 10)
+		require.NoError(t, err)
+		_, err = secondSeasonRepo.UpsertSeasonParticipant(1, 20)
+		require.NoError(t, err)
+		participant, err := secondSeasonRepo.UpsertSeasonParticipant(1, -500)
+		require.NoError(t, err)
+		assert.Equal(t, 1400, participant.Elo)
+
+		firstSeason, err := firstSeasonRepo.GetAll()
+		require.NoError(t, err)
+		assert.Len(t, firstSeason, 1)
+		assert.Equal(t, 1010, firstSeason[0].Elo)
+		assert.Equal(t, 1, firstSeason[0].GamesPlayed)
+		secondSeason, err := secondSeasonRepo.GetAll()
+		require.NoError(t, err)
+		assert.Len(t, secondSeason, 1)
+		assert.Equal(t, 1400, secondSeason[0].Elo)
+		assert.Equal(t, 2, secondSeason[0].GamesPlayed)
+	})
+
+	t.Run("Test create season that already exists", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+
+		err := seasonRepo.CreateSeason(&model.SeasonRules{
+			SeasonName:                "First Fan Faction Season",
+			KFactor:                   32,
+			StartElo:                  1000,
+			MinPlayers:                2,
+			MaxGameAgeDays:            30,
+			AllowedFanFactionSettings: "On - no Fire & Ice",
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, repository.ErrSeasonAlreadyExists))
+	})
+}
+
+func TestJoinSeason(t *testing.T) {
+	t.Parallel()
+	t.Run("Test join season before playing", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+
+		err := playerRepo.InsertPlayer("Test Player1", "1")
+		require.NoError(t, err)
+		participant, err := seasonRepo.JoinSeason(1)
+		require.NoError(t, err)
+		assert.Equal(t, 1000, participant.Elo)
+		assert.Equal(t, 0, participant.GamesPlayed)
+
+		_, err = seasonRepo.JoinSeason(1)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, repository.ErrAlreadyJoinedSeason))
+
+		_, err = seasonRepo.UpsertSeasonParticipant(1, 5)
+		require.NoError(t, err)
+		result, err := seasonRepo.GetAll()
+		require.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, 1005, result[0].Elo)
+		assert.Equal(t, 1, result[0].GamesPlayed)
+	})
+
+	t.Run("Test join season when player doesn't exist", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+
+		_, err := seasonRepo.JoinSeason(1)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "player or season does not exist")
+	})
+
+	t.Run("Test set sign up required", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+
+		err := seasonRepo.SetSignUpRequired(true)
+		require.NoError(t, err)
+		season, err := seasonRepo.GetCurrentSeason()
+		require.NoError(t, err)
+		assert.True(t, season.SignUpRequired)
+	})
+}
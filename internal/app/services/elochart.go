@@ -0,0 +1,199 @@
+package services
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"sort"
+	"strconv"
+	"tmff-discord-app/internal/app/services/model"
+
+	"github.com/pkg/errors"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	chartWidth        = 800
+	chartHeight       = 400
+	chartMarginLeft   = 50
+	chartMarginRight  = 20
+	chartMarginTop    = 30
+	chartMarginBottom = 20
+	chartEloStep      = 25
+	chartEloPadding   = 5
+	chartMaxGridLines = 8
+	chartLineWidth    = 2
+	chartLegendBox    = 10
+	chartLegendGap    = 20
+)
+
+//nolint:gochecknoglobals // Colors of the chart.
+var (
+	chartBackground = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	chartGrid       = color.RGBA{R: 225, G: 225, B: 225, A: 255}
+	chartAxis       = color.RGBA{R: 90, G: 90, B: 90, A: 255}
+	chartSeries     = []color.RGBA{
+		{R: 31, G: 119, B: 180, A: 255},
+		{R: 214, G: 39, B: 40, A: 255},
+		{R: 44, G: 160, B: 44, A: 255},
+		{R: 255, G: 127, B: 14, A: 255},
+		{R: 148, G: 103, B: 189, A: 255},
+		{R: 140, G: 86, B: 75, A: 255},
+	}
+)
+
+// RenderEloChart draws the Elo histories as a line chart in PNG format. The games of all players share the x axis in
+// the order they were registered, so the histories of several players can be compared.
+func RenderEloChart(histories []*model.EloHistory) ([]byte, error) {
+	if len(histories) == 0 {
+		return nil, errors.New("no Elo history to draw")
+	}
+	if len(histories) > len(chartSeries) {
+		return nil, errors.Errorf("at most %d players can be drawn in one chart", len(chartSeries))
+	}
+
+	gameIndexes := chartGameIndexes(histories)
+	minElo, maxElo := chartEloRange(histories)
+	plotWidth := chartWidth - chartMarginLeft - chartMarginRight
+	plotHeight := chartHeight - chartMarginTop - chartMarginBottom
+	xFor := func(index int) int {
+		return chartMarginLeft + index*plotWidth/len(gameIndexes)
+	}
+	yFor := func(elo int) int {
+		return chartMarginTop + (maxElo-elo)*plotHeight/(maxElo-minElo)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, chartWidth, chartHeight))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: chartBackground}, image.Point{}, draw.Src)
+
+	step := chartEloStep
+	for (maxElo-minElo)/step > chartMaxGridLines {
+		step += chartEloStep
+	}
+	for elo := minElo; elo <= maxElo; elo += step {
+		y := yFor(elo)
+		drawChartLine(img, chartMarginLeft, y, chartWidth-chartMarginRight, y, chartGrid, 1)
+		drawChartText(img, 4, y+4, strconv.Itoa(elo), chartAxis)
+	}
+	drawChartLine(img, chartMarginLeft, chartMarginTop, chartMarginLeft, chartHeight-chartMarginBottom, chartAxis, 1)
+	drawChartLine(
+		img,
+		chartMarginLeft,
+		chartHeight-chartMarginBottom,
+		chartWidth-chartMarginRight,
+		chartHeight-chartMarginBottom,
+		chartAxis,
+		1,
+	)
+
+	legendX := chartMarginLeft
+	for i, history := range histories {
+		seriesColor := chartSeries[i]
+		previousX, previousY := xFor(0), yFor(history.Points[0].Elo)
+		for _, point := range history.Points[1:] {
+			x, y := xFor(gameIndexes[point.GameID]), yFor(point.Elo)
+			drawChartLine(img, previousX, previousY, x, y, seriesColor, chartLineWidth)
+			previousX, previousY = x, y
+		}
+
+		legendBox := image.Rect(legendX, 10, legendX+chartLegendBox, 10+chartLegendBox)
+		draw.Draw(img, legendBox, &image.Uniform{C: seriesColor}, image.Point{}, draw.Src)
+		legendX = drawChartText(img, legendX+chartLegendBox+4, 20, history.PlayerName, chartAxis) + chartLegendGap
+	}
+
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode chart")
+	}
+	return buf.Bytes(), nil
+}
+
+// chartGameIndexes maps every game to its position on the x axis in the order the games were registered, position 0
+// is the start of the season. Games registered at the same time still get positions of their own.
+func chartGameIndexes(histories []*model.EloHistory) map[string]int {
+	participantIDs := make(map[string]int)
+	for _, history := range histories {
+		for _, point := range history.Points[1:] {
+			if participantID, ok := participantIDs[point.GameID]; !ok || point.ParticipantID < participantID {
+				participantIDs[point.GameID] = point.ParticipantID
+			}
+		}
+	}
+
+	gameIDs := make([]string, 0, len(participantIDs))
+	for gameID := range participantIDs {
+		gameIDs = append(gameIDs, gameID)
+	}
+	sort.Slice(gameIDs, func(i, j int) bool { return participantIDs[gameIDs[i]] < participantIDs[gameIDs[j]] })
+
+	indexes := make(map[string]int, len(gameIDs))
+	for i, gameID := range gameIDs {
+		indexes[gameID] = i + 1
+	}
+	return indexes
+}
+
+// chartEloRange returns the Elo range of the y axis, rounded to the grid step.
+func chartEloRange(histories []*model.EloHistory) (int, int) {
+	minElo, maxElo := histories[0].Points[0].Elo, histories[0].Points[0].Elo
+	for _, history := range histories {
+		for _, point := range history.Points {
+			minElo = min(minElo, point.Elo)
+			maxElo = max(maxElo, point.Elo)
+		}
+	}
+	// Keep some space between the lines and the edges of the plot
+	minElo = ((minElo - chartEloPadding) / chartEloStep) * chartEloStep
+	maxElo = ((maxElo+chartEloPadding)/chartEloStep + 1) * chartEloStep
+	return minElo, maxElo
+}
+
+// drawChartLine draws a line with Bresenham's algorithm, every point is drawn as a square of the given width.
+func drawChartLine(img *image.RGBA, x0, y0, x1, y1 int, lineColor color.RGBA, width int) {
+	dx := abs(x1 - x0)
+	dy := -abs(y1 - y0)
+	stepX, stepY := 1, 1
+	if x0 > x1 {
+		stepX = -1
+	}
+	if y0 > y1 {
+		stepY = -1
+	}
+	err := dx + dy
+	for {
+		for offsetX := range width {
+			for offsetY := range width {
+				img.SetRGBA(x0+offsetX, y0+offsetY, lineColor)
+			}
+		}
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		doubleErr := 2 * err
+		if doubleErr >= dy {
+			err += dy
+			x0 += stepX
+		}
+		if doubleErr <= dx {
+			err += dx
+			y0 += stepY
+		}
+	}
+}
+
+// drawChartText draws the text with its baseline at y and returns the x position after the text.
+func drawChartText(img *image.RGBA, x, y int, text string, textColor color.RGBA) int {
+	drawer := &font.Drawer{
+		Dst:  img,
+		Src:  &image.Uniform{C: textColor},
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(x, y),
+	}
+	drawer.DrawString(text)
+	return drawer.Dot.X.Round()
+}
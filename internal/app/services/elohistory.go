@@ -0,0 +1,59 @@
+package services
+
+import (
+	"tmff-discord-app/internal/app/repository"
+	"tmff-discord-app/internal/app/services/model"
+
+	"github.com/pkg/errors"
+)
+
+type EloHistory struct {
+	playerRepo *repository.Player
+	gameRepo   *repository.Game
+}
+
+func NewEloHistory(playerRepo *repository.Player, gameRepo *repository.Game) *EloHistory {
+	return &EloHistory{
+		playerRepo: playerRepo,
+		gameRepo:   gameRepo,
+	}
+}
+
+// GetEloHistory returns the player's Elo in the current season, starting with the Elo before the first game and
+// followed by a point for each registered game.
+func (e *EloHistory) GetEloHistory(playerName string) (*model.EloHistory, error) {
+	player, err := e.playerRepo.GetPlayer(playerName)
+	if err != nil {
+		return nil, err
+	}
+
+	participants, err := e.gameRepo.GetPlayerSeasonGameParticipants(player.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get games")
+	}
+
+	history := &model.EloHistory{
+		PlayerName: player.Name,
+	}
+	for _, participant := range participants {
+		if participant.PlayerID != player.ID {
+			continue
+		}
+		if len(history.Points) == 0 {
+			history.Points = append(history.Points, &model.EloPoint{
+				Time: participant.CreatedAt,
+				Elo:  participant.EloBefore,
+			})
+		}
+		history.Points = append(history.Points, &model.EloPoint{
+			GameID:        participant.GameID,
+			ParticipantID: participant.ID,
+			Time:          participant.CreatedAt,
+			Elo:           participant.EloBefore + participant.EloChange,
+		})
+	}
+	if len(history.Points) == 0 {
+		return nil, errors.Errorf("%s hasn't played any games this season", player.Name)
+	}
+	return history, nil
+}
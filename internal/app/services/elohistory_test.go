@@ -0,0 +1,108 @@
+package services_test
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+	"time"
+	"tmff-discord-app/internal/app/repository"
+	"tmff-discord-app/internal/app/services"
+	"tmff-discord-app/internal/app/services/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEloHistory(t *testing.T) {
+	t.Parallel()
+	t.Run("Test history and chart of two players", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
+		eloHistoryService := services.NewEloHistory(playerRepo, gameRepo)
+
+		err := playerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 2", "2")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 3", "3")
+		require.NoError(t, err)
+
+		_, err = eloHistoryService.GetEloHistory("Player 1")
+		require.Error(t, err)
+
+		currentTime := time.Now()
+		games := [][]*model.PlayerResult{
+			{{Name: "Player 1", Score: 150}, {Name: "Player 2", Score: 100}},
+			{{Name: "Player 2", Score: 110}, {Name: "Player 3", Score: 100}},
+			{{Name: "Player 1", Score: 120}, {Name: "Player 3", Score: 80}},
+		}
+		for i, players := range games {
+			_, err = gameService.RegisterGame(&model.GameOutcome{
+				ID:                string(rune('1' + i)),
+				Players:           players,
+				FanFactionSetting: model.On,
+				CreationTime:      &currentTime,
+			})
+			require.NoError(t, err)
+		}
+
+		history, err := eloHistoryService.GetEloHistory("Player 1")
+		require.NoError(t, err)
+		assert.Equal(t, "Player 1", history.PlayerName)
+		require.Len(t, history.Points, 3)
+		assert.Equal(t, 1000, history.Points[0].Elo)
+		assert.Equal(t, 1011, history.Points[1].Elo)
+		assert.Greater(t, history.Points[2].Elo, history.Points[1].Elo)
+
+		compared, err := eloHistoryService.GetEloHistory("Player 2")
+		require.NoError(t, err)
+		require.Len(t, compared.Points, 3)
+
+		gameIndexes := services.ChartGameIndexes([]*model.EloHistory{history, compared})
+		assert.Equal(t, map[string]int{"1": 1, "2": 2, "3": 3}, gameIndexes)
+
+		chart, err := services.RenderEloChart([]*model.EloHistory{history, compared})
+		require.NoError(t, err)
+		img, err := png.Decode(bytes.NewReader(chart))
+		require.NoError(t, err)
+		assert.Equal(t, 800, img.Bounds().Dx())
+		assert.Equal(t, 400, img.Bounds().Dy())
+	})
+
+	t.Run("Test chart positions games registered at the same time", func(t *testing.T) {
+		t.Parallel()
+		registeredAt := time.Now()
+		history := &model.EloHistory{
+			PlayerName: "Player 1",
+			Points: []*model.EloPoint{
+				{Time: registeredAt, Elo: 1000},
+				{GameID: "1", ParticipantID: 1, Time: registeredAt, Elo: 1032},
+				{GameID: "3", ParticipantID: 5, Time: registeredAt, Elo: 1050},
+			},
+		}
+		compared := &model.EloHistory{
+			PlayerName: "Player 2",
+			Points: []*model.EloPoint{
+				{Time: registeredAt, Elo: 1000},
+				{GameID: "1", ParticipantID: 2, Time: registeredAt, Elo: 968},
+				{GameID: "2", ParticipantID: 3, Time: registeredAt, Elo: 990},
+			},
+		}
+
+		gameIndexes := services.ChartGameIndexes([]*model.EloHistory{history, compared})
+		assert.Equal(t, map[string]int{"1": 1, "2": 2, "3": 3}, gameIndexes)
+		_, err := services.RenderEloChart([]*model.EloHistory{history, compared})
+		require.NoError(t, err)
+	})
+
+	t.Run("Test chart without histories", func(t *testing.T) {
+		t.Parallel()
+		_, err := services.RenderEloChart(nil)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"tmff-discord-app/internal/app/services/model"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// NewProfileScraperForPage returns a profile scraper that loads profiles in the page, the tests serve saved BGA pages
+// in it.
+func NewProfileScraperForPage(page playwright.Page) *ProfileScraper {
+	return newProfileScraper(page)
+}
+
+// NewGameScraperForPage returns a game scraper that reads the game shown in the page, the tests load saved BGA pages
+// into it.
+func NewGameScraperForPage(page playwright.Page) *GameScraper {
+	return newGameScraper(page)
+}
+
+// GetPlayerResults reads the score table of the game shown in the page.
+func (gs *GameScraper) GetPlayerResults() ([]*model.PlayerResult, error) {
+	return gs.getPlayerResults()
+}
+
+// ParsePlayerResult parses the name and score cells of a row of the score table.
+func ParsePlayerResult(name, score string) (*model.PlayerResult, error) {
+	return parsePlayerResult(name, score)
+}
+
+// ChartGameIndexes returns the positions of the games on the x axis of the Elo chart.
+func ChartGameIndexes(histories []*model.EloHistory) map[string]int {
+	return chartGameIndexes(histories)
+}
@@ -1,8 +1,10 @@
 package services
 
 import (
+	"fmt"
 	"math"
 	"sort"
+	"strings"
 	"tmff-discord-app/internal/app/repository"
 	repomodel "tmff-discord-app/internal/app/repository/model"
 	"tmff-discord-app/internal/app/services/model"
@@ -15,49 +17,94 @@ type PlayerIDToName map[int]string
 type PlayerIDToCurrentElo map[int]int
 type PlayerIDToEloChange map[int]int
 type PlayerIDToScore map[int]int
+type PlayerIDToDiscordID map[int]string
 
 type Game struct {
 	playerRepo *repository.Player
 	gameRepo   *repository.Game
 	seasonRepo *repository.Season
-	kValue     float64
 }
 
 func NewGame(
 	playerRepo *repository.Player,
 	gameRepo *repository.Game,
 	seasonRepo *repository.Season,
-	kValue int,
 ) *Game {
 	return &Game{
 		playerRepo: playerRepo,
 		gameRepo:   gameRepo,
 		seasonRepo: seasonRepo,
-		kValue:     float64(kValue),
 	}
 }
 
-func (g *Game) RegisterGame(gameOutcome *model.GameOutcome) ([]*model.PlayerEloResult, error) {
-	_, err := g.gameRepo.GetGameWithParticipants(gameOutcome.ID)
-	if !errors.Is(err, repository.ErrGameNotFound) {
+// gameRegistration is what rating a game needs once the game has been validated.
+type gameRegistration struct {
+	rules             *model.SeasonRules
+	registeredPlayers PlayerNameToID
+	discordIDs        PlayerIDToDiscordID
+}
+
+// ValidateGame checks that the game can be registered in the current season without registering it.
+func (g *Game) ValidateGame(gameOutcome *model.GameOutcome) error {
+	_, err := g.validateGame(gameOutcome)
+	return err
+}
+
+func (g *Game) validateGame(gameOutcome *model.GameOutcome) (*gameRegistration, error) {
+	season, err := g.seasonRepo.GetCurrentSeason()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get current season")
+	}
+	if season.EndedAt != nil {
+		return nil, errors.New("the season has ended, no more games can be registered")
+	}
+
+	rules, err := getSeasonRules(g.seasonRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	game, err := g.gameRepo.GetGameWithParticipants(gameOutcome.ID)
+	switch {
+	case err == nil && game.VoidedAt != nil:
+		return nil, errors.New("game has been voided")
+	case !errors.Is(err, repository.ErrGameNotFound):
 		return nil, errors.New("game already registered")
 	}
 
-	registeredPlayers, err := g.getRegisteredPlayers(gameOutcome)
+	registeredPlayers, discordIDs, err := g.getRegisteredPlayers(gameOutcome)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get registered players")
 	}
-	minimumPlayers := 2
-	if len(registeredPlayers) < minimumPlayers {
-		return nil, errors.New("less than two registered players found for game")
+	if season.SignUpRequired {
+		registeredPlayers, err = g.removePlayersNotInSeason(registeredPlayers)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get season participants")
+		}
+	}
+	if len(registeredPlayers) < rules.MinPlayers {
+		return nil, fmt.Errorf("less than %d registered players found for game", rules.MinPlayers)
 	}
+	return &gameRegistration{
+		rules:             rules,
+		registeredPlayers: registeredPlayers,
+		discordIDs:        discordIDs,
+	}, nil
+}
 
-	participantsRating, err := g.getPlayerElos(gameOutcome, registeredPlayers)
+func (g *Game) RegisterGame(gameOutcome *model.GameOutcome) ([]*model.PlayerEloResult, error) {
+	registration, err := g.validateGame(gameOutcome)
+	if err != nil {
+		return nil, err
+	}
+	rules, registeredPlayers, discordIDs := registration.rules, registration.registeredPlayers, registration.discordIDs
+
+	participantsRating, err := g.getPlayerElos(gameOutcome, registeredPlayers, rules.StartElo)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get player elos")
 	}
 
-	eloChangeMap := g.getEloChangeForPlayers(gameOutcome, participantsRating, registeredPlayers)
+	eloChangeMap := g.getEloChangeForPlayers(gameOutcome, participantsRating, registeredPlayers, rules.KFactor)
 
 	for playerID, eloChange := range eloChangeMap {
 		_, updateErr := g.seasonRepo.UpsertSeasonParticipant(playerID, eloChange)
@@ -67,7 +114,10 @@ func (g *Game) RegisterGame(gameOutcome *model.GameOutcome) ([]*model.PlayerEloR
 	}
 
 	playerScores := playerScoreByID(gameOutcome, registeredPlayers)
-	playerNamesByID := playerNameByID(registeredPlayers)
+	playerNamesByID, err := g.getPlayerNames()
+	if err != nil {
+		return nil, err
+	}
 
 	var gameParticipants []*repomodel.GameParticipant
 	var playerEloResults []*model.PlayerEloResult
@@ -82,6 +132,7 @@ func (g *Game) RegisterGame(gameOutcome *model.GameOutcome) ([]*model.PlayerEloR
 		playerEloResults = append(playerEloResults, &model.PlayerEloResult{
 			Name:      playerNamesByID[playerID],
 			ID:        playerID,
+			DiscordID: discordIDs[playerID],
 			Score:     playerScores[playerID],
 			EloBefore: participantsRating[playerID],
 			EloChange: eloChange,
@@ -99,6 +150,77 @@ func (g *Game) RegisterGame(gameOutcome *model.GameOutcome) ([]*model.PlayerEloR
 	return playerEloResults, nil
 }
 
+// VoidGame voids a game of the current season and rates the games registered after it again. The game is kept with
+// the moderator who voided it and the reason.
+func (g *Game) VoidGame(gameLink, reason, moderatorID string) (*model.VoidedGame, error) {
+	gameID, err := getTableID(gameLink)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid game link")
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errors.New("a reason is required to void a game")
+	}
+
+	game, err := g.gameRepo.GetGameWithParticipants(gameID)
+	if err != nil {
+		return nil, err
+	}
+	if game.VoidedAt != nil {
+		return nil, errors.New("game has already been voided")
+	}
+	season, err := g.seasonRepo.GetCurrentSeason()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get current season")
+	}
+	if game.SeasonName != season.Name || season.EndedAt != nil {
+		return nil, errors.New("only games of the current season can be voided")
+	}
+	rules, err := g.seasonRepo.GetRules()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get season rules")
+	}
+
+	participants, err := g.gameRepo.GetSeasonGameParticipants()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get game participants")
+	}
+	var games [][]*repomodel.GameParticipant
+	var gameParticipants []*repomodel.GameParticipant
+	voided, ratedAgain := false, 0
+	for _, seasonGame := range groupByGame(participants) {
+		if seasonGame[0].GameID == gameID {
+			voided = true
+			continue
+		}
+		if voided {
+			ratedAgain++
+		}
+		games = append(games, seasonGame)
+		gameParticipants = append(gameParticipants, seasonGame...)
+	}
+	seasonParticipants := replaySeason(season.Name, games, rules)
+
+	err = g.gameRepo.VoidGame(gameID, moderatorID, reason, rules.StartElo, gameParticipants, seasonParticipants)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to void game")
+	}
+
+	playerNames, err := g.getPlayerNames()
+	if err != nil {
+		return nil, err
+	}
+	voidedGame := &model.VoidedGame{
+		ID:         gameID,
+		Reason:     reason,
+		RatedAgain: ratedAgain,
+	}
+	for _, participant := range game.Participants {
+		voidedGame.PlayerNames = append(voidedGame.PlayerNames, playerNames[participant.PlayerID])
+	}
+	return voidedGame, nil
+}
+
 func playerScoreByID(gameOutcome *model.GameOutcome, idMap PlayerNameToID) PlayerIDToScore {
 	playerScore := make(PlayerIDToScore)
 	for _, player := range gameOutcome.Players {
@@ -107,69 +229,152 @@ func playerScoreByID(gameOutcome *model.GameOutcome, idMap PlayerNameToID) Playe
 	return playerScore
 }
 
-func playerNameByID(idMap PlayerNameToID) PlayerIDToName {
-	playerName := make(PlayerIDToName)
-	for name, id := range idMap {
-		playerName[id] = name
+// getPlayerNames returns the league names of the players, a player that played on an additional account is shown by
+// the name they are registered with.
+func (g *Game) getPlayerNames() (PlayerIDToName, error) {
+	players, err := g.playerRepo.GetPlayers()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get players")
+	}
+	playerNames := make(PlayerIDToName)
+	for _, player := range players {
+		playerNames[player.ID] = player.Name
 	}
-	return playerName
+	return playerNames, nil
 }
 
 func (g *Game) getEloChangeForPlayers(
 	gameOutcome *model.GameOutcome,
 	participantsRating PlayerIDToCurrentElo,
 	idMap PlayerNameToID,
+	kFactor int,
 ) PlayerIDToEloChange {
-	eloChangeForPlayerIDs := make(PlayerIDToEloChange)
-	for _, mainPlayer := range gameOutcome.Players {
-		if _, ok := participantsRating[idMap[mainPlayer.Name]]; !ok {
+	scores := make(PlayerIDToScore)
+	for _, player := range gameOutcome.Players {
+		playerID, ok := idMap[player.Name]
+		if !ok {
 			continue
 		}
-		for _, opponent := range gameOutcome.Players {
-			if mainPlayer.Name == opponent.Name {
-				continue
-			}
-			if _, ok := participantsRating[idMap[opponent.Name]]; !ok {
+		if _, ok = participantsRating[playerID]; !ok {
+			continue
+		}
+		scores[playerID] = player.Score
+	}
+	return calculateEloChanges(scores, participantsRating, kFactor)
+}
+
+// calculateEloChanges rates a game as a sub-match between every pair of players.
+func calculateEloChanges(scores PlayerIDToScore, ratings PlayerIDToCurrentElo, kFactor int) PlayerIDToEloChange {
+	eloChangeForPlayerIDs := make(PlayerIDToEloChange)
+	for playerID, playerScore := range scores {
+		for opponentID, opponentScore := range scores {
+			if playerID == opponentID {
 				continue
 			}
 			var score float64
 			switch {
-			case mainPlayer.Score > opponent.Score:
+			case playerScore > opponentScore:
 				score = 1
-			case mainPlayer.Score < opponent.Score:
+			case playerScore < opponentScore:
 				score = 0
-			case mainPlayer.Score == opponent.Score:
+			case playerScore == opponentScore:
 				score = 0.5
 			}
-			eloChange := g.calculateSubMatchEloChange(
-				participantsRating[idMap[mainPlayer.Name]],
-				participantsRating[idMap[opponent.Name]],
-				score,
-			)
-			eloChangeForPlayerIDs[idMap[mainPlayer.Name]] += eloChange
+			eloChange := calculateSubMatchEloChange(ratings[playerID], ratings[opponentID], score, kFactor)
+			eloChangeForPlayerIDs[playerID] += eloChange
 		}
 	}
 	return eloChangeForPlayerIDs
 }
 
-func (g *Game) getRegisteredPlayers(gameOutcome *model.GameOutcome) (PlayerNameToID, error) {
+// replayGames rates the games again in the order they were registered, a player starts at the start Elo of the season
+// of their first game and never drops below the Elo floor of the season of a game. The Elo of every game participant is
+// updated in place and the resulting ratings are returned.
+func replayGames(
+	games [][]*repomodel.GameParticipant,
+	seasonRules func(seasonName string) (*repomodel.SeasonRules, error),
+) (PlayerIDToCurrentElo, error) {
+	ratings := make(PlayerIDToCurrentElo)
+	for _, game := range games {
+		rules, err := seasonRules(game[0].SeasonName)
+		if err != nil {
+			return nil, err
+		}
+
+		scores := make(PlayerIDToScore)
+		for _, participant := range game {
+			if _, ok := ratings[participant.PlayerID]; !ok {
+				ratings[participant.PlayerID] = rules.StartElo
+			}
+			scores[participant.PlayerID] = participant.Score
+		}
+		eloChanges := calculateEloChanges(scores, ratings, rules.KFactor)
+		for _, participant := range game {
+			participant.EloBefore = ratings[participant.PlayerID]
+			participant.EloChange = eloChanges[participant.PlayerID]
+			ratings[participant.PlayerID] = max(ratings[participant.PlayerID]+eloChanges[participant.PlayerID], rules.EloFloor)
+		}
+	}
+	return ratings, nil
+}
+
+// getRegisteredPlayers resolves the BGA names in the game to registered players, including their additional accounts.
+// It also returns the Discord IDs of the linked players.
+func (g *Game) getRegisteredPlayers(gameOutcome *model.GameOutcome) (PlayerNameToID, PlayerIDToDiscordID, error) {
 	registeredPlayers := make(PlayerNameToID)
+	discordIDs := make(PlayerIDToDiscordID)
+	accountNames := make(PlayerIDToName)
 	for _, player := range gameOutcome.Players {
-		registeredPlayer, getPlayerErr := g.playerRepo.GetPlayer(player.Name)
+		registeredPlayer, getPlayerErr := g.playerRepo.GetPlayerByAccountName(player.Name)
 		if errors.Is(getPlayerErr, repository.ErrPlayerNotFound) {
 			continue
 		}
 		if getPlayerErr != nil {
-			return nil, getPlayerErr
+			return nil, nil, getPlayerErr
+		}
+		if !registeredPlayer.Active {
+			continue
+		}
+		if accountName, ok := accountNames[registeredPlayer.ID]; ok {
+			return nil, nil, fmt.Errorf(
+				"%s and %s are both accounts of %s, a player can only play once in a game",
+				accountName,
+				player.Name,
+				registeredPlayer.Name,
+			)
 		}
+		accountNames[registeredPlayer.ID] = player.Name
 		registeredPlayers[player.Name] = registeredPlayer.ID
+		if registeredPlayer.DiscordID != nil {
+			discordIDs[registeredPlayer.ID] = *registeredPlayer.DiscordID
+		}
+	}
+	return registeredPlayers, discordIDs, nil
+}
+
+// removePlayersNotInSeason drops the players that have not joined the season, they are rated as unregistered players.
+func (g *Game) removePlayersNotInSeason(registeredPlayers PlayerNameToID) (PlayerNameToID, error) {
+	seasonParticipants, err := g.seasonRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	joinedPlayers := make(PlayerNameToID)
+	for name, playerID := range registeredPlayers {
+		for _, participant := range seasonParticipants {
+			if participant.PlayerID == playerID {
+				joinedPlayers[name] = playerID
+				break
+			}
+		}
 	}
-	return registeredPlayers, nil
+	return joinedPlayers, nil
 }
 
 func (g *Game) getPlayerElos(
 	gameOutcome *model.GameOutcome,
 	registeredPlayers PlayerNameToID,
+	startElo int,
 ) (PlayerIDToCurrentElo, error) {
 	seasonParticipants, err := g.seasonRepo.GetAll()
 	if err != nil {
@@ -182,7 +387,7 @@ func (g *Game) getPlayerElos(
 		if !ok {
 			continue
 		}
-		participantsRating[registeredPlayers[gameParticipant.Name]] = repomodel.StartElo
+		participantsRating[registeredPlayers[gameParticipant.Name]] = startElo
 	}
 	for _, gameParticipant := range gameOutcome.Players {
 		for _, participant := range seasonParticipants {
@@ -198,10 +403,10 @@ func (g *Game) getPlayerElos(
 	return participantsRating, nil
 }
 
-func (g *Game) calculateSubMatchEloChange(playerRating, opponentRating int, actualScore float64) int {
+func calculateSubMatchEloChange(playerRating, opponentRating int, actualScore float64, kFactor int) int {
 	subMatchCount := 3.0
 	//nolint:mnd // 10 is the standard value for the base in the Elo formula
 	expectedScore := 1 / (1 + math.Pow(10, float64(opponentRating-playerRating)/400))
-	eloChange := (g.kValue * (actualScore - expectedScore)) / subMatchCount
+	eloChange := (float64(kFactor) * (actualScore - expectedScore)) / subMatchCount
 	return int(math.Round(eloChange))
 }
@@ -1,6 +1,7 @@
 package services_test
 
 import (
+	"strconv"
 	"testing"
 	"time"
 	"tmff-discord-app/internal/app/repository"
@@ -11,18 +12,16 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-const K = 64
-
 func TestRegisterGame(t *testing.T) {
 	t.Parallel()
 	t.Run("Register a new game - all players present", func(t *testing.T) {
 		t.Parallel()
 		dbx := newMigratedSQLiteDB(t)
 		queryTimeout := 2 * time.Second
-		gameRepo := repository.NewGame(dbx, &queryTimeout, "First Fan Faction Season")
-		playerRepo := repository.NewPlayer(dbx, &queryTimeout)
-		seasonRepo := repository.NewSeason(dbx, &queryTimeout, "First Fan Faction Season")
-		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo, K)
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
 
 		err := playerRepo.InsertPlayer("Player 1", "1")
 		require.NoError(t, err)
@@ -85,10 +84,10 @@ func TestRegisterGame(t *testing.T) {
 		t.Parallel()
 		dbx := newMigratedSQLiteDB(t)
 		queryTimeout := 2 * time.Second
-		gameRepo := repository.NewGame(dbx, &queryTimeout, "First Fan Faction Season")
-		playerRepo := repository.NewPlayer(dbx, &queryTimeout)
-		seasonRepo := repository.NewSeason(dbx, &queryTimeout, "First Fan Faction Season")
-		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo, K)
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
 
 		err := playerRepo.InsertPlayer("Player 1", "1")
 		require.NoError(t, err)
@@ -144,10 +143,10 @@ func TestRegisterGame(t *testing.T) {
 		t.Parallel()
 		dbx := newMigratedSQLiteDB(t)
 		queryTimeout := 2 * time.Second
-		gameRepo := repository.NewGame(dbx, &queryTimeout, "First Fan Faction Season")
-		playerRepo := repository.NewPlayer(dbx, &queryTimeout)
-		seasonRepo := repository.NewSeason(dbx, &queryTimeout, "First Fan Faction Season")
-		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo, K)
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
 
 		err := playerRepo.InsertPlayer("Player 1", "1")
 		require.NoError(t, err)
@@ -196,10 +195,10 @@ func TestRegisterGame(t *testing.T) {
 		t.Parallel()
 		dbx := newMigratedSQLiteDB(t)
 		queryTimeout := 2 * time.Second
-		gameRepo := repository.NewGame(dbx, &queryTimeout, "First Fan Faction Season")
-		playerRepo := repository.NewPlayer(dbx, &queryTimeout)
-		seasonRepo := repository.NewSeason(dbx, &queryTimeout, "First Fan Faction Season")
-		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo, K)
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
 
 		err := playerRepo.InsertPlayer("Player 4", "4")
 		require.NoError(t, err)
@@ -230,16 +229,16 @@ func TestRegisterGame(t *testing.T) {
 		}
 		_, err = gameService.RegisterGame(gameOutcome)
 		require.Error(t, err)
-		assert.Contains(t, err.Error(), "less than two registered players found for game")
+		assert.Contains(t, err.Error(), "less than 2 registered players found for game")
 	})
 	t.Run("Register a new game - no players present", func(t *testing.T) {
 		t.Parallel()
 		dbx := newMigratedSQLiteDB(t)
 		queryTimeout := 2 * time.Second
-		gameRepo := repository.NewGame(dbx, &queryTimeout, "First Fan Faction Season")
-		playerRepo := repository.NewPlayer(dbx, &queryTimeout)
-		seasonRepo := repository.NewSeason(dbx, &queryTimeout, "First Fan Faction Season")
-		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo, K)
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
 
 		currentTime := time.Now()
 		gameOutcome := &model.GameOutcome{
@@ -267,17 +266,54 @@ func TestRegisterGame(t *testing.T) {
 		}
 		_, err := gameService.RegisterGame(gameOutcome)
 		require.Error(t, err)
-		assert.Contains(t, err.Error(), "less than two registered players found for game")
+		assert.Contains(t, err.Error(), "less than 2 registered players found for game")
+	})
+
+	t.Run("Register a new game - player on an additional account", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
+
+		err := playerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 2", "2")
+		require.NoError(t, err)
+		err = playerRepo.AddAccount(1, "Player 1 Alt", "11")
+		require.NoError(t, err)
+
+		currentTime := time.Now()
+		gameOutcome := &model.GameOutcome{
+			ID: "1",
+			Players: []*model.PlayerResult{
+				{Name: "Player 1 Alt", Score: 200},
+				{Name: "Player 2", Score: 100},
+				{Name: "Player 3", Score: 90},
+				{Name: "Player 4", Score: 80},
+			},
+			FanFactionSetting: model.OnNoFireAndIce,
+			CreationTime:      &currentTime,
+		}
+		players, err := gameService.RegisterGame(gameOutcome)
+		require.NoError(t, err)
+		require.Len(t, players, 2)
+		assert.Equal(t, "Player 1", players[0].Name)
+		assert.Equal(t, 1, players[0].ID)
+		assert.Equal(t, 200, players[0].Score)
+		assert.Equal(t, "Player 2", players[1].Name)
 	})
 
 	t.Run("Multiple games for same players", func(t *testing.T) {
 		t.Parallel()
 		dbx := newMigratedSQLiteDB(t)
 		queryTimeout := 2 * time.Second
-		gameRepo := repository.NewGame(dbx, &queryTimeout, "First Fan Faction Season")
-		playerRepo := repository.NewPlayer(dbx, &queryTimeout)
-		seasonRepo := repository.NewSeason(dbx, &queryTimeout, "First Fan Faction Season")
-		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo, K)
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
 
 		err := playerRepo.InsertPlayer("Player 1", "1")
 		require.NoError(t, err)
@@ -438,3 +474,173 @@ func TestRegisterGame(t *testing.T) {
 		assert.Equal(t, -25, players[3].EloChange)
 	})
 }
+
+func TestValidateGame(t *testing.T) {
+	t.Parallel()
+	dbx := newMigratedSQLiteDB(t)
+	queryTimeout := 2 * time.Second
+	gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+	playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+	seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+	gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
+
+	err := playerRepo.InsertPlayer("Player 1", "1")
+	require.NoError(t, err)
+
+	currentTime := time.Now()
+	gameOutcome := &model.GameOutcome{
+		ID: "1",
+		Players: []*model.PlayerResult{
+			{
+				Name:  "Player 1",
+				Score: 100,
+			},
+			{
+				Name:  "Player 2",
+				Score: 200,
+			},
+		},
+		FanFactionSetting: model.OnNoFireAndIce,
+		CreationTime:      &currentTime,
+	}
+	err = gameService.ValidateGame(gameOutcome)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "less than 2 registered players found for game")
+
+	err = playerRepo.InsertPlayer("Player 2", "2")
+	require.NoError(t, err)
+	err = gameService.ValidateGame(gameOutcome)
+	require.NoError(t, err)
+
+	// Validating doesn't register the game
+	_, err = gameRepo.GetGameWithParticipants("1")
+	require.ErrorIs(t, err, repository.ErrGameNotFound)
+
+	_, err = gameService.RegisterGame(gameOutcome)
+	require.NoError(t, err)
+	err = gameService.ValidateGame(gameOutcome)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "game already registered")
+}
+
+func TestVoidGame(t *testing.T) {
+	t.Parallel()
+	newGameService := func(t *testing.T) (*services.Game, *repository.Season, *repository.Game) {
+		t.Helper()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		for i, name := range []string{"Player 1", "Player 2", "Player 3", "Player 4"} {
+			require.NoError(t, playerRepo.InsertPlayer(name, strconv.Itoa(i+1)))
+		}
+		return services.NewGame(playerRepo, gameRepo, seasonRepo), seasonRepo, gameRepo
+	}
+	currentTime := time.Now()
+	newGame := func(id string, first, second string) *model.GameOutcome {
+		return &model.GameOutcome{
+			ID: id,
+			Players: []*model.PlayerResult{
+				{Name: first, Score: 150},
+				{Name: second, Score: 100},
+			},
+			FanFactionSetting: model.On,
+			CreationTime:      &currentTime,
+		}
+	}
+	gameLink := func(id string) string {
+		return "https://boardgamearena.com/table?table=" + id
+	}
+
+	t.Run("Test later games are rated again", func(t *testing.T) {
+		t.Parallel()
+		gameService, seasonRepo, gameRepo := newGameService(t)
+		// The season is rated as if only the games after the voided game were registered
+		expectedService, expectedSeasonRepo, expectedGameRepo := newGameService(t)
+
+		_, err := gameService.RegisterGame(newGame("1", "Player 1", "Player 4"))
+		require.NoError(t, err)
+		for _, game := range []*model.GameOutcome{
+			newGame("2", "Player 1", "Player 2"),
+			newGame("3", "Player 2", "Player 3"),
+			newGame("4", "Player 3", "Player 1"),
+		} {
+			_, err = gameService.RegisterGame(game)
+			require.NoError(t, err)
+			_, err = expectedService.RegisterGame(game)
+			require.NoError(t, err)
+		}
+
+		voidedGame, err := gameService.VoidGame(gameLink("1"), " Played with a house rule ", "moderator-1")
+		require.NoError(t, err)
+		assert.Equal(t, "1", voidedGame.ID)
+		assert.Equal(t, "Played with a house rule", voidedGame.Reason)
+		assert.ElementsMatch(t, []string{"Player 1", "Player 4"}, voidedGame.PlayerNames)
+		assert.Equal(t, 3, voidedGame.RatedAgain)
+
+		expectedParticipants, err := expectedSeasonRepo.GetAll()
+		require.NoError(t, err)
+		participants, err := seasonRepo.GetAll()
+		require.NoError(t, err)
+		require.Len(t, participants, 4)
+		for _, expected := range expectedParticipants {
+			participant, getErr := seasonRepo.GetSeasonParticipant(expected.PlayerID)
+			require.NoError(t, getErr)
+			assert.Equal(t, expected.Elo, participant.Elo, expected.PlayerID)
+			assert.Equal(t, expected.GamesPlayed, participant.GamesPlayed, expected.PlayerID)
+		}
+		// Player 4 only played the voided game
+		participant, err := seasonRepo.GetSeasonParticipant(4)
+		require.NoError(t, err)
+		assert.Equal(t, 1000, participant.Elo)
+		assert.Equal(t, 0, participant.GamesPlayed)
+
+		expectedGames, err := expectedGameRepo.GetSeasonGameParticipants()
+		require.NoError(t, err)
+		games, err := gameRepo.GetSeasonGameParticipants()
+		require.NoError(t, err)
+		require.Len(t, games, len(expectedGames))
+		for _, expected := range expectedGames {
+			for _, participant := range games {
+				if participant.GameID != expected.GameID || participant.PlayerID != expected.PlayerID {
+					continue
+				}
+				assert.Equal(t, expected.EloBefore, participant.EloBefore, expected.GameID)
+				assert.Equal(t, expected.EloChange, participant.EloChange, expected.GameID)
+			}
+		}
+
+		// The voided game is kept
+		game, err := gameRepo.GetGameWithParticipants("1")
+		require.NoError(t, err)
+		assert.NotNil(t, game.VoidedAt)
+		assert.Equal(t, "Played with a house rule", game.VoidReason)
+		assert.Len(t, game.Participants, 2)
+	})
+
+	t.Run("Test voided game can't be voided or registered again", func(t *testing.T) {
+		t.Parallel()
+		gameService, _, _ := newGameService(t)
+
+		_, err := gameService.VoidGame(gameLink("1"), "Cheating", "moderator-1")
+		require.ErrorIs(t, err, repository.ErrGameNotFound)
+
+		_, err = gameService.RegisterGame(newGame("1", "Player 1", "Player 2"))
+		require.NoError(t, err)
+		_, err = gameService.VoidGame(gameLink("1"), " ", "moderator-1")
+		require.Error(t, err)
+		_, err = gameService.VoidGame("https://boardgamearena.com/player?id=1", "Cheating", "moderator-1")
+		require.Error(t, err)
+
+		_, err = gameService.VoidGame(gameLink("1"), "Cheating", "moderator-1")
+		require.NoError(t, err)
+		_, err = gameService.VoidGame(gameLink("1"), "Cheating", "moderator-1")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already been voided")
+
+		err = gameService.ValidateGame(newGame("1", "Player 1", "Player 2"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "game has been voided")
+	})
+}
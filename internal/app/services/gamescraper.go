@@ -3,32 +3,32 @@ package services
 import (
 	"fmt"
 	"net/url"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
 	"tmff-discord-app/internal/app/services/model"
+	"tmff-discord-app/pkg/retry"
 
 	"github.com/pkg/errors"
 	"github.com/playwright-community/playwright-go"
 )
 
 type GameScraper struct {
-	page           playwright.Page
-	maxGameAgeDays int
+	page playwright.Page
 }
 
-func newGameScraper(page playwright.Page, maxGameAgeDays int) *GameScraper {
+func newGameScraper(page playwright.Page) *GameScraper {
 	return &GameScraper{
-		page:           page,
-		maxGameAgeDays: maxGameAgeDays,
+		page: page,
 	}
 }
 
-func (gs *GameScraper) ExtractGameOutcome(inputURL string) (*model.GameOutcome, error) {
+// ExtractGameOutcome scrapes the outcome of a game and validates it against the given season rules. Errors that
+// scraping the game again can't fix, like a game of another board game, are marked permanent for retry.Retry.
+func (gs *GameScraper) ExtractGameOutcome(inputURL string, rules *model.SeasonRules) (*model.GameOutcome, error) {
 	tableID, err := getTableID(inputURL)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get table ID from URL")
+		return nil, retry.Permanent(errors.Wrap(err, "failed to get table ID from URL"))
 	}
 	gameURL := fmt.Sprintf("https://en.boardgamearena.com/table?table=%s", tableID)
 	if _, err = gs.page.Goto(gameURL); err != nil {
@@ -40,12 +40,12 @@ func (gs *GameScraper) ExtractGameOutcome(inputURL string) (*model.GameOutcome,
 		return nil, errors.Wrap(err, "failed to check if table exists")
 	}
 	if !exists {
-		return nil, errors.New("table does not exist")
+		return nil, retry.Permanent(errors.New("table does not exist"))
 	}
 
 	err = gs.assertIsTerraMystica()
 	if err != nil {
-		return nil, errors.Wrap(err, "game is not Terra Mystica")
+		return nil, retry.Permanent(errors.Wrap(err, "game is not Terra Mystica"))
 	}
 
 	fanFactionSetting, err := gs.getFanFactionSetting()
@@ -68,9 +68,9 @@ func (gs *GameScraper) ExtractGameOutcome(inputURL string) (*model.GameOutcome,
 		FanFactionSetting: model.FanFactionSettingFromString(fanFactionSetting),
 		CreationTime:      creationTime,
 	}
-	err = outcome.Validate(gs.maxGameAgeDays)
+	err = outcome.Validate(rules)
 	if err != nil {
-		return nil, errors.Wrap(err, "game outcome is invalid")
+		return nil, retry.Permanent(errors.Wrap(err, "game outcome is invalid"))
 	}
 	return outcome, nil
 }
@@ -101,13 +101,35 @@ func (gs *GameScraper) getFanFactionSetting() (string, error) {
 	return selectedText, nil
 }
 
+// getPlayerResults reads the results from the score table of the game. Names are taken as they are shown, so they can
+// contain any character, including parentheses.
 func (gs *GameScraper) getPlayerResults() ([]*model.PlayerResult, error) {
-	resultElement := gs.page.Locator(`meta[property="og:description"][content*="1°"]`)
-	results, err := resultElement.GetAttribute("content")
+	entries, err := gs.page.Locator("#game_result .score-entity").All()
 	if err != nil {
-		return nil, errors.Wrap(err, "could not get content attribute")
+		return nil, errors.Wrap(err, "could not find game results")
 	}
-	return extractPlayers(results)
+	expectedPlayerCount := 4
+	if len(entries) != expectedPlayerCount {
+		return nil, retry.Permanent(errors.New("invalid number of players"))
+	}
+
+	players := make([]*model.PlayerResult, 0, len(entries))
+	for _, entry := range entries {
+		name, nameErr := entry.Locator(".playername").First().TextContent()
+		if nameErr != nil {
+			return nil, errors.Wrap(nameErr, "could not get player name")
+		}
+		score, scoreErr := entry.Locator(".score").First().TextContent()
+		if scoreErr != nil {
+			return nil, errors.Wrap(scoreErr, "could not get player score")
+		}
+		player, parseErr := parsePlayerResult(name, score)
+		if parseErr != nil {
+			return nil, retry.Permanent(parseErr)
+		}
+		players = append(players, player)
+	}
+	return players, nil
 }
 
 func (gs *GameScraper) getCreationTime() (*time.Time, error) {
@@ -144,28 +166,24 @@ func (gs *GameScraper) assertIsTerraMystica() error {
 	return errors.New("game name is not Terra Mystica")
 }
 
-func extractPlayers(input string) ([]*model.PlayerResult, error) {
-	re := regexp.MustCompile(`\d+°\s+([^\(]+)\s+\((\d+)\s+pts\)`)
-	matches := re.FindAllStringSubmatch(input, -1)
-	expectedPlayerCount := 4
-	if len(matches) != expectedPlayerCount {
-		return nil, errors.New("invalid number of players")
-	}
-	var players []*model.PlayerResult
-	for _, match := range matches {
-		//nolint:mnd // We need to check if the match has at least 2 groups
-		if len(match) > 2 {
-			score, err := strconv.Atoi(match[2])
-			if err != nil {
-				return nil, err
-			}
-			players = append(players, &model.PlayerResult{
-				Name:  match[1],
-				Score: score,
-			})
-		}
+func parsePlayerResult(name, score string) (*model.PlayerResult, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("player name is missing")
 	}
-	return players, nil
+	// Only the leading number is the score, the cell can contain more text after it
+	fields := strings.Fields(score)
+	if len(fields) == 0 {
+		return nil, errors.Errorf("score of %s is missing", name)
+	}
+	points, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid score of %s", name)
+	}
+	return &model.PlayerResult{
+		Name:  name,
+		Score: points,
+	}, nil
 }
 
 func (gs *GameScraper) tableExists() (bool, error) {
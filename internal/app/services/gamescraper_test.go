@@ -1,14 +1,23 @@
 package services_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"tmff-discord-app/internal/app/services"
+	"tmff-discord-app/internal/app/services/model"
 
 	"github.com/playwright-community/playwright-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+//nolint:gochecknoglobals // Rules that accept the old games used in the tests.
+var testRules = &model.SeasonRules{
+	MaxGameAgeDays:            100000,
+	AllowedFanFactionSettings: []model.FanFactionSetting{model.On, model.OnNoFireAndIce},
+}
+
 func TestMain(m *testing.M) {
 	err := playwright.Install()
 	if err != nil {
@@ -24,7 +33,7 @@ func TestExtractGameOutcome(t *testing.T) {
 		gameScraper := createGameScraper(t)
 		defer gameScraper.Close()
 
-		gameOutcome, err := gameScraper.ExtractGameOutcome("https://boardgamearena.com/table?table=572461868")
+		gameOutcome, err := gameScraper.ExtractGameOutcome("https://boardgamearena.com/table?table=572461868", testRules)
 		require.NoError(t, err)
 
 		assert.Equal(t, "On - no Fire & Ice", string(gameOutcome.FanFactionSetting))
@@ -44,7 +53,7 @@ func TestExtractGameOutcome(t *testing.T) {
 		gameScraper := createGameScraper(t)
 		defer gameScraper.Close()
 
-		gameOutcome, err := gameScraper.ExtractGameOutcome("https://boardgamearena.com/table?table=559705570")
+		gameOutcome, err := gameScraper.ExtractGameOutcome("https://boardgamearena.com/table?table=559705570", testRules)
 		require.NoError(t, err)
 
 		assert.Equal(t, "On - with Fire & Ice", string(gameOutcome.FanFactionSetting))
@@ -65,7 +74,7 @@ func TestExtractGameOutcome(t *testing.T) {
 		gameScraper := createGameScraper(t)
 		defer gameScraper.Close()
 
-		_, err := gameScraper.ExtractGameOutcome("https://boardgamearena.com/table?table=544240084")
+		_, err := gameScraper.ExtractGameOutcome("https://boardgamearena.com/table?table=544240084", testRules)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "game name is not Terra Mystica")
 	})
@@ -75,7 +84,7 @@ func TestExtractGameOutcome(t *testing.T) {
 		gameScraper := createGameScraper(t)
 		defer gameScraper.Close()
 
-		_, err := gameScraper.ExtractGameOutcome("https://boardgamearena.com/table?table=570819150")
+		_, err := gameScraper.ExtractGameOutcome("https://boardgamearena.com/table?table=570819150", testRules)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "fan factions are not enabled")
 	})
@@ -85,7 +94,7 @@ func TestExtractGameOutcome(t *testing.T) {
 		gameScraper := createGameScraper(t)
 		defer gameScraper.Close()
 
-		_, err := gameScraper.ExtractGameOutcome("https://boardgamearena.com/table?table=557774225")
+		_, err := gameScraper.ExtractGameOutcome("https://boardgamearena.com/table?table=557774225", testRules)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "invalid number of players")
 	})
@@ -94,9 +103,10 @@ func TestExtractGameOutcome(t *testing.T) {
 		gameScraper := createGameScraper(t)
 		defer gameScraper.Close()
 
-		_, err := gameScraper.ExtractGameOutcome("https://boardgamearena.com/table?table=555675245")
+		_, err := gameScraper.ExtractGameOutcome("https://boardgamearena.com/table?table=555675245", testRules)
 		require.Error(t, err)
-		assert.Contains(t, err.Error(), "game outcome is invalid: player 3 has no score")
+		// The score table lists the player that left the game without a score
+		assert.Regexp(t, `failed to get player results: (score of .+ is missing|player name is missing)`, err.Error())
 	})
 
 	t.Run("table does not exist", func(t *testing.T) {
@@ -104,7 +114,7 @@ func TestExtractGameOutcome(t *testing.T) {
 		gameScraper := createGameScraper(t)
 		defer gameScraper.Close()
 
-		_, err := gameScraper.ExtractGameOutcome("https://boardgamearena.com/table?table=555555555555555")
+		_, err := gameScraper.ExtractGameOutcome("https://boardgamearena.com/table?table=555555555555555", testRules)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "table does not exist")
 	})
@@ -114,7 +124,7 @@ func TestExtractGameOutcome(t *testing.T) {
 		gameScraper := createGameScraper(t)
 		defer gameScraper.Close()
 
-		_, err := gameScraper.ExtractGameOutcome("123")
+		_, err := gameScraper.ExtractGameOutcome("123", testRules)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to get table ID from URL")
 	})
@@ -124,7 +134,7 @@ func TestExtractGameOutcome(t *testing.T) {
 		gameScraper := createGameScraper(t)
 		defer gameScraper.Close()
 
-		_, err := gameScraper.ExtractGameOutcome("https://boardgamearena.com/table")
+		_, err := gameScraper.ExtractGameOutcome("https://boardgamearena.com/table", testRules)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to get table ID from URL")
 	})
@@ -134,7 +144,7 @@ func TestExtractGameOutcome(t *testing.T) {
 		gameScraper := createGameScraper(t)
 		defer gameScraper.Close()
 
-		gameOutcome, err := gameScraper.ExtractGameOutcome("https://boardgamearena.com/table?table=572461868&a=b")
+		gameOutcome, err := gameScraper.ExtractGameOutcome("https://boardgamearena.com/table?table=572461868&a=b", testRules)
 		require.NoError(t, err)
 		assert.Len(t, gameOutcome.Players, 4)
 	})
@@ -144,13 +154,107 @@ func TestExtractGameOutcome(t *testing.T) {
 		gameScraper := createGameScraper(t)
 		defer gameScraper.Close()
 
-		gameOutcome, err := gameScraper.ExtractGameOutcome("sv.boardgamearena.com//table?table=572461868&a=b")
+		gameOutcome, err := gameScraper.ExtractGameOutcome("sv.boardgamearena.com//table?table=572461868&a=b", testRules)
 		require.NoError(t, err)
 		assert.Len(t, gameOutcome.Players, 4)
 	})
 }
 
+func TestGetPlayerResults(t *testing.T) {
+	t.Parallel()
+	t.Run("synthetic score table", func(t *testing.T) {
+		t.Parallel()
+		gameScraper := createFixtureGameScraper(t, "bga_table_result.html")
+		defer gameScraper.Close()
+
+		players, err := gameScraper.GetPlayerResults()
+		require.NoError(t, err)
+		require.Len(t, players, 4)
+		assert.Equal(t, "Stahlbrötchen", players[0].Name)
+		assert.Equal(t, 148, players[0].Score)
+		assert.Equal(t, "deragned (NL)", players[1].Name)
+		assert.Equal(t, 146, players[1].Score)
+		assert.Equal(t, "skoomymooms", players[2].Name)
+		assert.Equal(t, 133, players[2].Score)
+		assert.Equal(t, "Zaarito", players[3].Name)
+		assert.Equal(t, 100, players[3].Score)
+	})
+
+	t.Run("synthetic score table with three players", func(t *testing.T) {
+		t.Parallel()
+		gameScraper := createFixtureGameScraper(t, "bga_table_result_three_players.html")
+		defer gameScraper.Close()
+
+		_, err := gameScraper.GetPlayerResults()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid number of players")
+	})
+}
+
+func TestParsePlayerResult(t *testing.T) {
+	t.Parallel()
+	t.Run("name and score are trimmed", func(t *testing.T) {
+		t.Parallel()
+		player, err := services.ParsePlayerResult("\n  Stahlbrötchen  \n", " 148 ")
+		require.NoError(t, err)
+		assert.Equal(t, "Stahlbrötchen", player.Name)
+		assert.Equal(t, 148, player.Score)
+	})
+
+	t.Run("text after the score is ignored", func(t *testing.T) {
+		t.Parallel()
+		player, err := services.ParsePlayerResult("deragned (NL)", "146 (tie breaker: 3)")
+		require.NoError(t, err)
+		assert.Equal(t, "deragned (NL)", player.Name)
+		assert.Equal(t, 146, player.Score)
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		t.Parallel()
+		_, err := services.ParsePlayerResult(" ", "148")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "player name is missing")
+	})
+
+	t.Run("missing score", func(t *testing.T) {
+		t.Parallel()
+		_, err := services.ParsePlayerResult("Zaarito", " ")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "score of Zaarito is missing")
+	})
+
+	t.Run("invalid score", func(t *testing.T) {
+		t.Parallel()
+		_, err := services.ParsePlayerResult("Zaarito", "-")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid score of Zaarito")
+	})
+}
+
 func createGameScraper(t *testing.T) *services.GameScraper {
+	pages := services.NewPages(createBrowser(t))
+	t.Cleanup(func() {
+		pages.Close()
+	})
+
+	gameScraper, err := pages.NewGameScraper()
+	require.NoError(t, err)
+	return gameScraper
+}
+
+// createFixtureGameScraper returns a game scraper that reads a synthetic page from testdata instead of a live table.
+// The fixtures are written after the markup of the BGA score table, they are not captures of real pages.
+func createFixtureGameScraper(t *testing.T, fixture string) *services.GameScraper {
+	page, err := createBrowser(t).NewPage()
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join("testdata", fixture))
+	require.NoError(t, err)
+	err = page.SetContent(string(content))
+	require.NoError(t, err)
+	return services.NewGameScraperForPage(page)
+}
+
+func createBrowser(t *testing.T) playwright.Browser {
 	pw, err := playwright.Run()
 	require.NoError(t, err)
 	t.Cleanup(func() {
@@ -165,13 +269,5 @@ func createGameScraper(t *testing.T) *services.GameScraper {
 	t.Cleanup(func() {
 		browser.Close()
 	})
-
-	pages := services.NewPages(browser, 100000)
-	t.Cleanup(func() {
-		pages.Close()
-	})
-
-	gameScraper, err := pages.NewGameScraper()
-	require.NoError(t, err)
-	return gameScraper
+	return browser
 }
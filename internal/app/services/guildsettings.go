@@ -0,0 +1,75 @@
+package services
+
+import (
+	"strings"
+	"time"
+	"tmff-discord-app/internal/app/repository"
+	repomodel "tmff-discord-app/internal/app/repository/model"
+	"tmff-discord-app/internal/app/services/model"
+
+	"github.com/pkg/errors"
+)
+
+const moderatorRoleSeparator = ","
+
+type GuildSettings struct {
+	guildSettingsRepo *repository.GuildSettings
+}
+
+func NewGuildSettings(guildSettingsRepo *repository.GuildSettings) *GuildSettings {
+	return &GuildSettings{
+		guildSettingsRepo: guildSettingsRepo,
+	}
+}
+
+// Get returns the settings of the guild, a guild that has not configured anything gets the default settings.
+func (g *GuildSettings) Get(guildID string) (*model.GuildSettings, error) {
+	settings, err := g.guildSettingsRepo.Get(guildID)
+	if errors.Is(err, repository.ErrGuildSettingsNotFound) {
+		return model.DefaultGuildSettings(guildID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var moderatorRoleIDs []string
+	if settings.ModeratorRoleIDs != "" {
+		moderatorRoleIDs = strings.Split(settings.ModeratorRoleIDs, moderatorRoleSeparator)
+	}
+	return &model.GuildSettings{
+		GuildID:       settings.GuildID,
+		CurrentSeason: settings.CurrentSeason,
+		ChannelIDs: map[model.Channel]string{
+			model.GamesChannel:             settings.GamesChannelID,
+			model.LeaderboardChannel:       settings.LeaderboardChannelID,
+			model.RegisteredPlayersChannel: settings.RegisteredPlayersChannelID,
+			model.StaffChannel:             settings.StaffChannelID,
+		},
+		ModeratorRoleIDs: moderatorRoleIDs,
+		RateLimit:        time.Duration(settings.RateLimitMinutes) * time.Minute,
+		PublicResults:    settings.PublicResults,
+	}, nil
+}
+
+func (g *GuildSettings) Save(settings *model.GuildSettings) error {
+	if settings.RateLimit < 0 {
+		return errors.New("rate limit can't be negative")
+	}
+	return g.guildSettingsRepo.Upsert(&repomodel.GuildSettings{
+		GuildID:                    settings.GuildID,
+		GamesChannelID:             settings.ChannelIDs[model.GamesChannel],
+		LeaderboardChannelID:       settings.ChannelIDs[model.LeaderboardChannel],
+		RegisteredPlayersChannelID: settings.ChannelIDs[model.RegisteredPlayersChannel],
+		StaffChannelID:             settings.ChannelIDs[model.StaffChannel],
+		ModeratorRoleIDs:           strings.Join(settings.ModeratorRoleIDs, moderatorRoleSeparator),
+		RateLimitMinutes:           int(settings.RateLimit / time.Minute),
+		PublicResults:              settings.PublicResults,
+		CurrentSeason:              settings.CurrentSeason,
+	})
+}
+
+// AssignUnscopedData makes the guild the owner of the league data that was created before leagues were scoped by
+// guild, the guild starts in the current season if it has none.
+func (g *GuildSettings) AssignUnscopedData(guildID, currentSeason string) error {
+	return g.guildSettingsRepo.AssignUnscopedData(guildID, currentSeason)
+}
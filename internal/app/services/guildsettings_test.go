@@ -0,0 +1,81 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+	"tmff-discord-app/internal/app/repository"
+	"tmff-discord-app/internal/app/services"
+	"tmff-discord-app/internal/app/services/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuildSettings(t *testing.T) {
+	t.Parallel()
+	t.Run("Test default settings", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		guildSettingsService := services.NewGuildSettings(repository.NewGuildSettings(dbx, &queryTimeout))
+
+		settings, err := guildSettingsService.Get("guild-1")
+		require.NoError(t, err)
+		assert.Equal(t, "guild-1", settings.GuildID)
+		assert.Empty(t, settings.ChannelIDs[model.GamesChannel])
+		assert.Empty(t, settings.ModeratorRoleIDs)
+		assert.Equal(t, time.Hour, settings.RateLimit)
+		assert.True(t, settings.PublicResults)
+	})
+
+	t.Run("Test save settings", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		guildSettingsService := services.NewGuildSettings(repository.NewGuildSettings(dbx, &queryTimeout))
+
+		settings, err := guildSettingsService.Get("guild-1")
+		require.NoError(t, err)
+		settings.ChannelIDs[model.GamesChannel] = "channel-1"
+		settings.AddModeratorRole("role-1")
+		settings.AddModeratorRole("role-2")
+		settings.AddModeratorRole("role-1")
+		settings.RateLimit = 15 * time.Minute
+		settings.PublicResults = false
+		err = guildSettingsService.Save(settings)
+		require.NoError(t, err)
+
+		settings, err = guildSettingsService.Get("guild-1")
+		require.NoError(t, err)
+		assert.Equal(t, "channel-1", settings.ChannelIDs[model.GamesChannel])
+		assert.Empty(t, settings.ChannelIDs[model.LeaderboardChannel])
+		assert.Equal(t, []string{"role-1", "role-2"}, settings.ModeratorRoleIDs)
+		assert.Equal(t, 15*time.Minute, settings.RateLimit)
+		assert.False(t, settings.PublicResults)
+
+		settings.RemoveModeratorRole("role-1")
+		err = guildSettingsService.Save(settings)
+		require.NoError(t, err)
+		settings, err = guildSettingsService.Get("guild-1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"role-2"}, settings.ModeratorRoleIDs)
+
+		// Other guilds keep their own settings
+		otherSettings, err := guildSettingsService.Get("guild-2")
+		require.NoError(t, err)
+		assert.True(t, otherSettings.PublicResults)
+	})
+
+	t.Run("Test negative rate limit", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		guildSettingsService := services.NewGuildSettings(repository.NewGuildSettings(dbx, &queryTimeout))
+
+		settings, err := guildSettingsService.Get("guild-1")
+		require.NoError(t, err)
+		settings.RateLimit = -time.Minute
+		err = guildSettingsService.Save(settings)
+		require.Error(t, err)
+	})
+}
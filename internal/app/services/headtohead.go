@@ -0,0 +1,90 @@
+package services
+
+import (
+	"fmt"
+	"tmff-discord-app/internal/app/repository"
+	repomodel "tmff-discord-app/internal/app/repository/model"
+	"tmff-discord-app/internal/app/services/model"
+
+	"github.com/pkg/errors"
+)
+
+type HeadToHead struct {
+	playerRepo *repository.Player
+	gameRepo   *repository.Game
+	seasonRepo *repository.Season
+}
+
+func NewHeadToHead(
+	playerRepo *repository.Player,
+	gameRepo *repository.Game,
+	seasonRepo *repository.Season,
+) *HeadToHead {
+	return &HeadToHead{
+		playerRepo: playerRepo,
+		gameRepo:   gameRepo,
+		seasonRepo: seasonRepo,
+	}
+}
+
+// GetHeadToHead returns the record of player A against player B across all seasons. The Elo exchanged in a game is
+// the rating change of the sub-match between the two players only, rated with the rules of the game's season.
+func (h *HeadToHead) GetHeadToHead(playerAName, playerBName string) (*model.HeadToHead, error) {
+	playerA, err := h.playerRepo.GetPlayer(playerAName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get player %s", playerAName)
+	}
+	playerB, err := h.playerRepo.GetPlayer(playerBName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get player %s", playerBName)
+	}
+	if playerA.ID == playerB.ID {
+		return nil, errors.New("pick two different players")
+	}
+
+	games, err := h.gameRepo.GetHeadToHeadGames(playerA.ID, playerB.ID)
+	if err != nil {
+		return nil, err
+	}
+	allRules, err := h.seasonRepo.GetAllRules()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get season rules")
+	}
+	rulesBySeason := make(map[string]*repomodel.SeasonRules)
+	for _, rules := range allRules {
+		rulesBySeason[rules.SeasonName] = rules
+	}
+
+	headToHead := &model.HeadToHead{
+		PlayerA: playerA.Name,
+		PlayerB: playerB.Name,
+	}
+	for _, game := range games {
+		rules, ok := rulesBySeason[game.SeasonName]
+		if !ok {
+			return nil, fmt.Errorf("no rules found for season %s", game.SeasonName)
+		}
+		var score float64
+		switch {
+		case game.ScoreA > game.ScoreB:
+			score = 1
+			headToHead.WinsA++
+		case game.ScoreA < game.ScoreB:
+			score = 0
+			headToHead.WinsB++
+		default:
+			score = 0.5
+			headToHead.Draws++
+		}
+		eloExchanged := calculateSubMatchEloChange(game.EloBeforeA, game.EloBeforeB, score, rules.KFactor)
+		headToHead.NetElo += eloExchanged
+		headToHead.Games = append(headToHead.Games, &model.HeadToHeadGame{
+			GameID:       game.GameID,
+			SeasonName:   game.SeasonName,
+			ScoreA:       game.ScoreA,
+			ScoreB:       game.ScoreB,
+			EloExchanged: eloExchanged,
+		})
+	}
+	return headToHead, nil
+}
@@ -0,0 +1,76 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+	"tmff-discord-app/internal/app/repository"
+	"tmff-discord-app/internal/app/services"
+	"tmff-discord-app/internal/app/services/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetHeadToHead(t *testing.T) {
+	t.Parallel()
+	t.Run("Test record of two players", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
+		headToHeadService := services.NewHeadToHead(playerRepo, gameRepo, seasonRepo)
+
+		err := playerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 2", "2")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 3", "3")
+		require.NoError(t, err)
+
+		currentTime := time.Now()
+		games := [][]*model.PlayerResult{
+			{{Name: "Player 1", Score: 150}, {Name: "Player 2", Score: 100}},
+			{{Name: "Player 1", Score: 100}, {Name: "Player 2", Score: 120}, {Name: "Player 3", Score: 90}},
+			{{Name: "Player 2", Score: 110}, {Name: "Player 3", Score: 100}},
+			{{Name: "Player 1", Score: 100}, {Name: "Player 2", Score: 100}},
+		}
+		for i, players := range games {
+			_, err = gameService.RegisterGame(&model.GameOutcome{
+				ID:                string(rune('1' + i)),
+				Players:           players,
+				FanFactionSetting: model.On,
+				CreationTime:      &currentTime,
+			})
+			require.NoError(t, err)
+		}
+
+		headToHead, err := headToHeadService.GetHeadToHead("Player 1", "Player 2")
+		require.NoError(t, err)
+		require.Len(t, headToHead.Games, 3)
+		assert.Equal(t, 1, headToHead.WinsA)
+		assert.Equal(t, 1, headToHead.WinsB)
+		assert.Equal(t, 1, headToHead.Draws)
+		assert.Equal(t, "1", headToHead.Games[0].GameID)
+		assert.Equal(t, 50, headToHead.Games[0].Margin())
+		assert.Equal(t, 11, headToHead.Games[0].EloExchanged)
+		assert.Equal(t, -20, headToHead.Games[1].Margin())
+		assert.Equal(t, "4", headToHead.Games[2].GameID)
+
+		netElo := 0
+		for _, game := range headToHead.Games {
+			netElo += game.EloExchanged
+		}
+		assert.Equal(t, netElo, headToHead.NetElo)
+
+		reversed, err := headToHeadService.GetHeadToHead("Player 2", "Player 1")
+		require.NoError(t, err)
+		assert.Equal(t, -headToHead.NetElo, reversed.NetElo)
+		assert.Equal(t, headToHead.WinsA, reversed.WinsB)
+
+		_, err = headToHeadService.GetHeadToHead("Player 1", "Player 1")
+		require.Error(t, err)
+	})
+}
@@ -3,22 +3,38 @@ package services
 import (
 	"sort"
 	"tmff-discord-app/internal/app/repository"
+	repomodel "tmff-discord-app/internal/app/repository/model"
 	"tmff-discord-app/internal/app/services/model"
+
+	"github.com/pkg/errors"
 )
 
 type Leaderboard struct {
 	seasonRepo *repository.Season
 	playerRepo *repository.Player
+	gameRepo   *repository.Game
 }
 
-func NewLeaderboard(seasonRepo *repository.Season, playerRepo *repository.Player) *Leaderboard {
+func NewLeaderboard(
+	seasonRepo *repository.Season,
+	playerRepo *repository.Player,
+	gameRepo *repository.Game,
+) *Leaderboard {
 	return &Leaderboard{
 		seasonRepo: seasonRepo,
 		playerRepo: playerRepo,
+		gameRepo:   gameRepo,
 	}
 }
 
+// GetLeaderboard ranks the players that have played the season's minimum number of games,
+// the remaining players are listed as unqualified.
 func (l *Leaderboard) GetLeaderboard() (*model.Leaderboard, error) {
+	season, err := l.seasonRepo.GetCurrentSeason()
+	if err != nil {
+		return nil, err
+	}
+
 	seasonParticipants, err := l.seasonRepo.GetAll()
 	if err != nil {
 		return nil, err
@@ -29,14 +45,15 @@ func (l *Leaderboard) GetLeaderboard() (*model.Leaderboard, error) {
 		return nil, err
 	}
 
-	leaderboardEntries := make([]*model.LeaderboardEntry, len(seasonParticipants))
+	leaderboardEntries := make([]*model.LeaderboardEntry, 0, len(seasonParticipants))
+	var unqualifiedEntries []*model.LeaderboardEntry
 
 	// sort by elo
 	sort.Slice(seasonParticipants, func(i, j int) bool {
 		return seasonParticipants[i].Elo > seasonParticipants[j].Elo
 	})
 
-	for i, participant := range seasonParticipants {
+	for _, participant := range seasonParticipants {
 		var playerName string
 		for _, player := range players {
 			if player.ID == participant.PlayerID {
@@ -44,14 +61,114 @@ func (l *Leaderboard) GetLeaderboard() (*model.Leaderboard, error) {
 				break
 			}
 		}
-		leaderboardEntries[i] = &model.LeaderboardEntry{
+		entry := &model.LeaderboardEntry{
+			PlayerID:    participant.PlayerID,
 			PlayerName:  playerName,
 			Elo:         participant.Elo,
 			GamesPlayed: participant.GamesPlayed,
 		}
+		if participant.GamesPlayed < season.MinGames {
+			entry.GamesNeeded = season.MinGames - participant.GamesPlayed
+			unqualifiedEntries = append(unqualifiedEntries, entry)
+			continue
+		}
+		leaderboardEntries = append(leaderboardEntries, entry)
 	}
 
 	return &model.Leaderboard{
-		Entries: leaderboardEntries,
+		Entries:     leaderboardEntries,
+		Unqualified: unqualifiedEntries,
+		MinGames:    season.MinGames,
+	}, nil
+}
+
+// GetAllTimeLeaderboard ranks every player across all seasons. The all-time Elo never resets, it is calculated by
+// replaying every game in the order they were registered with the rules of the game's season.
+func (l *Leaderboard) GetAllTimeLeaderboard() (*model.AllTimeLeaderboard, error) {
+	participants, err := l.gameRepo.GetAllGameParticipants()
+	if err != nil {
+		return nil, err
+	}
+
+	allRules, err := l.seasonRepo.GetAllRules()
+	if err != nil {
+		return nil, err
+	}
+	rulesBySeason := make(map[string]*repomodel.SeasonRules)
+	for _, rules := range allRules {
+		rulesBySeason[rules.SeasonName] = rules
+	}
+
+	players, err := l.playerRepo.GetPlayers()
+	if err != nil {
+		return nil, err
+	}
+	playerNames := make(PlayerIDToName)
+	for _, player := range players {
+		playerNames[player.ID] = player.Name
+	}
+
+	games := groupByGame(participants)
+	ratings, err := replayGames(games, func(seasonName string) (*repomodel.SeasonRules, error) {
+		rules, ok := rulesBySeason[seasonName]
+		if !ok {
+			return nil, errors.Errorf("no rules found for season %s", seasonName)
+		}
+		return rules, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entriesByPlayerID := make(map[int]*model.AllTimeLeaderboardEntry)
+	positionSums := make(map[int]int)
+	for _, game := range games {
+		for _, participant := range game {
+			entry, ok := entriesByPlayerID[participant.PlayerID]
+			if !ok {
+				entry = &model.AllTimeLeaderboardEntry{
+					PlayerID:   participant.PlayerID,
+					PlayerName: playerNames[participant.PlayerID],
+				}
+				entriesByPlayerID[participant.PlayerID] = entry
+			}
+			position := finishingPosition(participant, game)
+			if position == 1 {
+				entry.Wins++
+			}
+			positionSums[participant.PlayerID] += position
+			entry.GamesPlayed++
+		}
+	}
+
+	entries := make([]*model.AllTimeLeaderboardEntry, 0, len(entriesByPlayerID))
+	for playerID, entry := range entriesByPlayerID {
+		entry.Elo = ratings[playerID]
+		entry.AveragePosition = float64(positionSums[playerID]) / float64(entry.GamesPlayed)
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Elo != entries[j].Elo {
+			return entries[i].Elo > entries[j].Elo
+		}
+		if entries[i].GamesPlayed != entries[j].GamesPlayed {
+			return entries[i].GamesPlayed > entries[j].GamesPlayed
+		}
+		return entries[i].PlayerID < entries[j].PlayerID
+	})
+
+	return &model.AllTimeLeaderboard{
+		Entries: entries,
 	}, nil
 }
+
+// finishingPosition is the player's position among the registered players of the game, tied players share a position.
+func finishingPosition(participant *repomodel.GameParticipant, game []*repomodel.GameParticipant) int {
+	position := 1
+	for _, opponent := range game {
+		if opponent.Score > participant.Score {
+			position++
+		}
+	}
+	return position
+}
@@ -18,11 +18,11 @@ func TestGetLeaderboard(t *testing.T) {
 
 		dbx := newMigratedSQLiteDB(t)
 		queryTimeout := 2 * time.Second
-		gameRepo := repository.NewGame(dbx, &queryTimeout, "First Fan Faction Season")
-		playerRepo := repository.NewPlayer(dbx, &queryTimeout)
-		seasonRepo := repository.NewSeason(dbx, &queryTimeout, "First Fan Faction Season")
-		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo, K)
-		leaderboardService := services.NewLeaderboard(seasonRepo, playerRepo)
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
+		leaderboardService := services.NewLeaderboard(seasonRepo, playerRepo, gameRepo)
 
 		err := playerRepo.InsertPlayer("Player 1", "1")
 		require.NoError(t, err)
@@ -156,4 +156,167 @@ func TestGetLeaderboard(t *testing.T) {
 		assert.Equal(t, 923, leaderboardEntries[3].Elo)
 		assert.Equal(t, 3, leaderboardEntries[3].GamesPlayed)
 	})
+
+	t.Run("Test minimum games", func(t *testing.T) {
+		t.Parallel()
+
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
+		leaderboardService := services.NewLeaderboard(seasonRepo, playerRepo, gameRepo)
+
+		err := playerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 2", "2")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 3", "3")
+		require.NoError(t, err)
+		err = seasonRepo.SetMinGames(2)
+		require.NoError(t, err)
+
+		currentTime := time.Now()
+		gameOutcome1 := &model.GameOutcome{
+			ID: "1",
+			Players: []*model.PlayerResult{
+				{
+					Name:  "Player 1",
+					Score: 100,
+				},
+				{
+					Name:  "Player 2",
+					Score: 200,
+				},
+				{
+					Name:  "Player 3",
+					Score: 300,
+				},
+			},
+			FanFactionSetting: model.On,
+			CreationTime:      &currentTime,
+		}
+		_, err = gameService.RegisterGame(gameOutcome1)
+		require.NoError(t, err)
+
+		gameOutcome2 := &model.GameOutcome{
+			ID: "2",
+			Players: []*model.PlayerResult{
+				{
+					Name:  "Player 1",
+					Score: 100,
+				},
+				{
+					Name:  "Player 2",
+					Score: 200,
+				},
+			},
+			FanFactionSetting: model.On,
+			CreationTime:      &currentTime,
+		}
+		_, err = gameService.RegisterGame(gameOutcome2)
+		require.NoError(t, err)
+
+		leaderboard, err := leaderboardService.GetLeaderboard()
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, leaderboard.MinGames)
+		assert.Len(t, leaderboard.Entries, 2)
+		assert.Equal(t, "Player 2", leaderboard.Entries[0].PlayerName)
+		assert.Equal(t, "Player 1", leaderboard.Entries[1].PlayerName)
+		assert.Len(t, leaderboard.Unqualified, 1)
+		assert.Equal(t, "Player 3", leaderboard.Unqualified[0].PlayerName)
+		assert.Equal(t, 1, leaderboard.Unqualified[0].GamesNeeded)
+		assert.Contains(t, leaderboard.String(), "Unqualified (minimum 2 games)")
+	})
+}
+
+func TestGetAllTimeLeaderboard(t *testing.T) {
+	t.Parallel()
+	t.Run("Test Elo carries over between seasons", func(t *testing.T) {
+		t.Parallel()
+
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		firstSeasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		secondSeasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "Second Fan Faction Season")
+		firstGameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		secondGameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "Second Fan Faction Season")
+		firstGameService := services.NewGame(playerRepo, firstGameRepo, firstSeasonRepo)
+		secondGameService := services.NewGame(playerRepo, secondGameRepo, secondSeasonRepo)
+		leaderboardService := services.NewLeaderboard(secondSeasonRepo, playerRepo, secondGameRepo)
+
+		err := services.NewSeason(firstSeasonRepo, playerRepo).CreateSeason(&model.SeasonRules{
+			SeasonName:                "Second Fan Faction Season",
+			KFactor:                   64,
+			StartElo:                  1000,
+			EloFloor:                  0,
+			MinPlayers:                2,
+			MaxGameAgeDays:            60,
+			AllowedFanFactionSettings: []model.FanFactionSetting{model.On},
+		})
+		require.NoError(t, err)
+
+		err = playerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 2", "2")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 3", "3")
+		require.NoError(t, err)
+
+		currentTime := time.Now()
+		_, err = firstGameService.RegisterGame(&model.GameOutcome{
+			ID: "1",
+			Players: []*model.PlayerResult{
+				{Name: "Player 1", Score: 150},
+				{Name: "Player 2", Score: 100},
+			},
+			FanFactionSetting: model.On,
+			CreationTime:      &currentTime,
+		})
+		require.NoError(t, err)
+		_, err = secondGameService.RegisterGame(&model.GameOutcome{
+			ID: "2",
+			Players: []*model.PlayerResult{
+				{Name: "Player 1", Score: 150},
+				{Name: "Player 2", Score: 120},
+				{Name: "Player 3", Score: 120},
+			},
+			FanFactionSetting: model.On,
+			CreationTime:      &currentTime,
+		})
+		require.NoError(t, err)
+
+		leaderboard, err := leaderboardService.GetAllTimeLeaderboard()
+		require.NoError(t, err)
+		entries := leaderboard.Entries
+
+		assert.Len(t, entries, 3)
+		assert.Equal(t, "Player 1", entries[0].PlayerName)
+		assert.Equal(t, 1031, entries[0].Elo)
+		assert.Equal(t, 2, entries[0].GamesPlayed)
+		assert.Equal(t, 2, entries[0].Wins)
+		assert.InDelta(t, 1.0, entries[0].AveragePosition, 0.001)
+		assert.Equal(t, "Player 3", entries[1].PlayerName)
+		assert.Equal(t, 990, entries[1].Elo)
+		assert.Equal(t, 1, entries[1].GamesPlayed)
+		assert.InDelta(t, 2.0, entries[1].AveragePosition, 0.001)
+		assert.Equal(t, "Player 2", entries[2].PlayerName)
+		assert.Equal(t, 979, entries[2].Elo)
+		assert.Equal(t, 0, entries[2].Wins)
+		assert.InDelta(t, 2.0, entries[2].AveragePosition, 0.001)
+
+		export, err := leaderboard.CSV()
+		require.NoError(t, err)
+		assert.Equal(t, "Rank,Player Name,Elo,Games,Wins,Avg Pos\n"+
+			"1,Player 1,1031,2,2,1.00\n"+
+			"2,Player 3,990,1,0,2.00\n"+
+			"3,Player 2,979,2,0,2.00\n", string(export))
+
+		seasonLeaderboard, err := leaderboardService.GetLeaderboard()
+		require.NoError(t, err)
+		assert.Len(t, seasonLeaderboard.Entries, 3)
+	})
 }
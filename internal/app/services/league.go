@@ -0,0 +1,63 @@
+package services
+
+import (
+	"time"
+	"tmff-discord-app/internal/app/repository"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// League holds the services of the league of a guild, games are registered in the guild's current season.
+type League struct {
+	GuildID      string
+	PlayerRepo   *repository.Player
+	Players      *Player
+	Games        *Game
+	Seasons      *Season
+	Leaderboard  *Leaderboard
+	SeasonReport *SeasonReport
+	Profile      *Profile
+	EloHistory   *EloHistory
+	HeadToHead   *HeadToHead
+}
+
+// Leagues keeps the leagues of every guild apart, each guild has its own players, seasons and games.
+type Leagues struct {
+	db            *sqlx.DB
+	queryTimeout  *time.Duration
+	guildSettings *GuildSettings
+}
+
+func NewLeagues(db *sqlx.DB, queryTimeout *time.Duration, guildSettings *GuildSettings) *Leagues {
+	return &Leagues{
+		db:            db,
+		queryTimeout:  queryTimeout,
+		guildSettings: guildSettings,
+	}
+}
+
+// Get returns the league of the guild in its current season.
+func (l *Leagues) Get(guildID string) (*League, error) {
+	settings, err := l.guildSettings.Get(guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get guild settings")
+	}
+
+	playerRepo := repository.NewPlayer(l.db, l.queryTimeout, guildID)
+	seasonRepo := repository.NewSeason(l.db, l.queryTimeout, guildID, settings.CurrentSeason)
+	gameRepo := repository.NewGame(l.db, l.queryTimeout, guildID, settings.CurrentSeason)
+	leaderboard := NewLeaderboard(seasonRepo, playerRepo, gameRepo)
+	return &League{
+		GuildID:      guildID,
+		PlayerRepo:   playerRepo,
+		Players:      NewPlayer(playerRepo, gameRepo, seasonRepo),
+		Games:        NewGame(playerRepo, gameRepo, seasonRepo),
+		Seasons:      NewSeason(seasonRepo, playerRepo),
+		Leaderboard:  leaderboard,
+		SeasonReport: NewSeasonReport(seasonRepo, gameRepo, playerRepo, leaderboard),
+		Profile:      NewProfile(playerRepo, seasonRepo, gameRepo, leaderboard),
+		EloHistory:   NewEloHistory(playerRepo, gameRepo),
+		HeadToHead:   NewHeadToHead(playerRepo, gameRepo, seasonRepo),
+	}, nil
+}
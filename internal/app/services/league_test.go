@@ -0,0 +1,168 @@
+package services_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+	"tmff-discord-app/internal/app/repository"
+	"tmff-discord-app/internal/app/services"
+	"tmff-discord-app/internal/app/services/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeagues(t *testing.T) {
+	t.Parallel()
+	t.Run("Test the current season of the guild", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		guildSettingsService := services.NewGuildSettings(repository.NewGuildSettings(dbx, &queryTimeout))
+		leagues := services.NewLeagues(dbx, &queryTimeout, guildSettingsService)
+
+		league, err := leagues.Get(testGuildID)
+		require.NoError(t, err)
+		rules, err := league.Seasons.GetRules()
+		require.NoError(t, err)
+		assert.Equal(t, "First Fan Faction Season", rules.SeasonName)
+
+		// A guild without a season starts from the default rules
+		league, err = leagues.Get("guild-2")
+		require.NoError(t, err)
+		_, err = league.Seasons.GetRules()
+		require.Error(t, err)
+		rules, err = league.Seasons.GetNextSeasonRules()
+		require.NoError(t, err)
+		assert.Equal(t, 64, rules.KFactor)
+
+		rules.SeasonName = "Second Guild Season"
+		err = league.Seasons.CreateSeason(rules)
+		require.NoError(t, err)
+		league, err = leagues.Get("guild-2")
+		require.NoError(t, err)
+		rules, err = league.Seasons.GetRules()
+		require.NoError(t, err)
+		assert.Equal(t, "Second Guild Season", rules.SeasonName)
+
+		// The new season doesn't replace the current season of a guild until it has ended
+		league, err = leagues.Get(testGuildID)
+		require.NoError(t, err)
+		rules.SeasonName = "Second Fan Faction Season"
+		err = league.Seasons.CreateSeason(rules)
+		require.NoError(t, err)
+		settings, err := guildSettingsService.Get(testGuildID)
+		require.NoError(t, err)
+		assert.Equal(t, "First Fan Faction Season", settings.CurrentSeason)
+	})
+
+	t.Run("Test leagues don't share players or games", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		guildSettingsService := services.NewGuildSettings(repository.NewGuildSettings(dbx, &queryTimeout))
+		leagues := services.NewLeagues(dbx, &queryTimeout, guildSettingsService)
+
+		league, err := leagues.Get(testGuildID)
+		require.NoError(t, err)
+		err = league.PlayerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+		err = league.PlayerRepo.InsertPlayer("Player 2", "2")
+		require.NoError(t, err)
+
+		otherLeague, err := leagues.Get("guild-2")
+		require.NoError(t, err)
+		rules := model.DefaultSeasonRules()
+		rules.SeasonName = "Second Guild Season"
+		err = otherLeague.Seasons.CreateSeason(rules)
+		require.NoError(t, err)
+		otherLeague, err = leagues.Get("guild-2")
+		require.NoError(t, err)
+		_, err = otherLeague.PlayerRepo.GetPlayer("Player 1")
+		require.ErrorIs(t, err, repository.ErrPlayerNotFound)
+
+		// The same BGA accounts can play in both leagues
+		err = otherLeague.PlayerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+		err = otherLeague.PlayerRepo.InsertPlayer("Player 2", "2")
+		require.NoError(t, err)
+
+		currentTime := time.Now()
+		_, err = league.Games.RegisterGame(&model.GameOutcome{
+			ID: "1",
+			Players: []*model.PlayerResult{
+				{Name: "Player 1", Score: 100},
+				{Name: "Player 2", Score: 200},
+			},
+			FanFactionSetting: model.OnNoFireAndIce,
+			CreationTime:      &currentTime,
+		})
+		require.NoError(t, err)
+
+		leaderboard, err := league.Leaderboard.GetLeaderboard()
+		require.NoError(t, err)
+		assert.Len(t, append(leaderboard.Entries, leaderboard.Unqualified...), 2)
+		otherLeaderboard, err := otherLeague.Leaderboard.GetLeaderboard()
+		require.NoError(t, err)
+		assert.Empty(t, append(otherLeaderboard.Entries, otherLeaderboard.Unqualified...))
+		_, err = otherLeague.EloHistory.GetEloHistory("Player 1")
+		require.Error(t, err)
+	})
+	t.Run("Test leagues can use the same table IDs and season names", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		guildSettingsService := services.NewGuildSettings(repository.NewGuildSettings(dbx, &queryTimeout))
+		leagues := services.NewLeagues(dbx, &queryTimeout, guildSettingsService)
+
+		otherLeague, err := leagues.Get("guild-2")
+		require.NoError(t, err)
+		rules := model.DefaultSeasonRules()
+		rules.SeasonName = "First Fan Faction Season"
+		err = otherLeague.Seasons.CreateSeason(rules)
+		require.NoError(t, err)
+		otherLeague, err = leagues.Get("guild-2")
+		require.NoError(t, err)
+
+		currentTime := time.Now()
+		results := make(map[string][]*model.PlayerEloResult)
+		for guildID, players := range map[string][]string{
+			testGuildID: {"Player 1", "Player 2"},
+			"guild-2":   {"Player 3", "Player 4"},
+		} {
+			league, err := leagues.Get(guildID)
+			require.NoError(t, err)
+			for i, name := range players {
+				err = league.PlayerRepo.InsertPlayer(name, strconv.Itoa(i+1))
+				require.NoError(t, err)
+			}
+			results[guildID], err = league.Games.RegisterGame(&model.GameOutcome{
+				ID: "1",
+				Players: []*model.PlayerResult{
+					{Name: players[0], Score: 100},
+					{Name: players[1], Score: 200},
+				},
+				FanFactionSetting: model.OnNoFireAndIce,
+				CreationTime:      &currentTime,
+			})
+			require.NoError(t, err)
+		}
+		assert.Len(t, results[testGuildID], 2)
+		assert.Len(t, results["guild-2"], 2)
+
+		league, err := leagues.Get(testGuildID)
+		require.NoError(t, err)
+		for l, names := range map[*services.League][]string{
+			league:      {"Player 1", "Player 2"},
+			otherLeague: {"Player 3", "Player 4"},
+		} {
+			leaderboard, err := l.Leaderboard.GetLeaderboard()
+			require.NoError(t, err)
+			entries := append(leaderboard.Entries, leaderboard.Unqualified...)
+			require.Len(t, entries, 2)
+			for _, entry := range entries {
+				assert.Contains(t, names, entry.PlayerName)
+			}
+		}
+	})
+}
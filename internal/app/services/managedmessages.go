@@ -0,0 +1,44 @@
+package services
+
+import (
+	"tmff-discord-app/internal/app/repository"
+	repomodel "tmff-discord-app/internal/app/repository/model"
+)
+
+// Names of the messages the bot keeps up to date in every guild.
+const (
+	LeaderboardMessage = "leaderboard"
+	PlayersMessage     = "players"
+)
+
+// ManagedMessages tracks the messages the bot keeps up to date, so the pages of a table are edited in place instead
+// of being searched for in the channel.
+type ManagedMessages struct {
+	managedMessageRepo *repository.ManagedMessage
+}
+
+func NewManagedMessages(managedMessageRepo *repository.ManagedMessage) *ManagedMessages {
+	return &ManagedMessages{
+		managedMessageRepo: managedMessageRepo,
+	}
+}
+
+// GetPages returns the tracked pages of the message in page order.
+func (m *ManagedMessages) GetPages(guildID, name string) ([]*repomodel.ManagedMessage, error) {
+	return m.managedMessageRepo.GetMessages(guildID, name)
+}
+
+func (m *ManagedMessages) SavePage(guildID, name string, page int, channelID, messageID string) error {
+	return m.managedMessageRepo.Upsert(&repomodel.ManagedMessage{
+		GuildID:   guildID,
+		Name:      name,
+		Page:      page,
+		ChannelID: channelID,
+		MessageID: messageID,
+	})
+}
+
+// RemovePagesFrom stops tracking the pages of the message starting at the page, after the table has shrunk.
+func (m *ManagedMessages) RemovePagesFrom(guildID, name string, page int) error {
+	return m.managedMessageRepo.DeleteFrom(guildID, name, page)
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+type AllTimeLeaderboard struct {
+	Entries []*AllTimeLeaderboardEntry
+}
+
+type AllTimeLeaderboardEntry struct {
+	PlayerID        int
+	PlayerName      string
+	Elo             int
+	GamesPlayed     int
+	Wins            int
+	AveragePosition float64
+}
+
+func (l *AllTimeLeaderboard) String() string {
+	var output string
+	output += fmt.Sprintf("%s\n", "All-time Leaderboard")
+	header := fmt.Sprintf("%-4s %-20s %4s %5s %4s %7s\n", "Rank", "Player Name", "Elo", "Games", "Wins", "Avg Pos")
+	output += header
+	output += fmt.Sprintf("%s\n", "----------------------------------------------------")
+	for index, entry := range l.Entries {
+		playerNameTruncated := truncateString(entry.PlayerName, 20)
+		output += fmt.Sprintf(
+			"%-4d %-20s %4d %5d %4d %7.2f\n",
+			index+1,
+			playerNameTruncated,
+			entry.Elo,
+			entry.GamesPlayed,
+			entry.Wins,
+			entry.AveragePosition,
+		)
+	}
+	return output
+}
+
+// CSV exports the leaderboard with a header row and one row per player, names aren't truncated.
+func (l *AllTimeLeaderboard) CSV() ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	err := w.Write([]string{"Rank", "Player Name", "Elo", "Games", "Wins", "Avg Pos"})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not write header")
+	}
+	for index, entry := range l.Entries {
+		err = w.Write([]string{
+			strconv.Itoa(index + 1),
+			entry.PlayerName,
+			strconv.Itoa(entry.Elo),
+			strconv.Itoa(entry.GamesPlayed),
+			strconv.Itoa(entry.Wins),
+			strconv.FormatFloat(entry.AveragePosition, 'f', 2, 64),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "could not write entry")
+		}
+	}
+	w.Flush()
+	if err = w.Error(); err != nil {
+		return nil, errors.Wrap(err, "could not write csv")
+	}
+	return buf.Bytes(), nil
+}
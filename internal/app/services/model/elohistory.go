@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// EloHistory is the Elo of a player in a season. The first point is the Elo before the first game, followed by the
+// Elo after each game.
+type EloHistory struct {
+	PlayerName string
+	Points     []*EloPoint
+}
+
+// EloPoint is the Elo of a player after a game. ParticipantID orders the games, it grows with every registered game,
+// while several games can be registered at the same time.
+type EloPoint struct {
+	GameID        string
+	ParticipantID int
+	Time          time.Time
+	Elo           int
+}
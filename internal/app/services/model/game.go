@@ -1,5 +1,7 @@
 package model
 
+import "fmt"
+
 type Player struct {
 	Name string
 	ID   string
@@ -11,7 +13,21 @@ type MatchResult struct {
 type PlayerEloResult struct {
 	Name      string
 	ID        int
+	DiscordID string
 	Score     int
 	EloBefore int
 	EloChange int
 }
+
+// VoidedGame is a game a moderator voided, the Elo changes of its players were reverted.
+type VoidedGame struct {
+	ID          string
+	Reason      string
+	PlayerNames []string
+	// RatedAgain is the number of games registered after the voided game, they were rated again without it
+	RatedAgain int
+}
+
+func (g *VoidedGame) BGALink() string {
+	return fmt.Sprintf("https://boardgamearena.com/table?table=%s", g.ID)
+}
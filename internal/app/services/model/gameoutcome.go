@@ -32,7 +32,8 @@ func (g *GameOutcome) String() string {
 	return output
 }
 
-func (g *GameOutcome) Validate(maxGameAgeDays int) error {
+// Validate checks that the game outcome is complete and was played according to the season rules.
+func (g *GameOutcome) Validate(rules *SeasonRules) error {
 	for i, player := range g.Players {
 		if player.Name == "" {
 			return fmt.Errorf("player %d has no name", i)
@@ -41,13 +42,16 @@ func (g *GameOutcome) Validate(maxGameAgeDays int) error {
 			return fmt.Errorf("player %d has no score", i)
 		}
 	}
-	if g.FanFactionSetting != On && g.FanFactionSetting != OnNoFireAndIce {
-		return errors.New("fan factions are not enabled")
+	if !rules.AllowsFanFactionSetting(g.FanFactionSetting) {
+		if g.FanFactionSetting == Off {
+			return errors.New("fan factions are not enabled")
+		}
+		return fmt.Errorf("fan faction setting %s is not allowed this season", g.FanFactionSetting)
 	}
 	//nolint:mnd // 24 hours in a day
 	oneDay := 24 * time.Hour
-	if g.CreationTime.Before(time.Now().Add(-time.Duration(maxGameAgeDays) * oneDay)) {
-		return errors.New("game is too old (more than 60 days)")
+	if g.CreationTime.Before(time.Now().Add(-time.Duration(rules.MaxGameAgeDays) * oneDay)) {
+		return fmt.Errorf("game is too old (more than %d days)", rules.MaxGameAgeDays)
 	}
 	return nil
 }
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Channel is a channel the bot posts to, its value is the name the channel is looked up by when its ID is not set.
+type Channel string
+
+const (
+	GamesChannel             Channel = "games"
+	LeaderboardChannel       Channel = "leaderboard"
+	RegisteredPlayersChannel Channel = "registered-players"
+	StaffChannel             Channel = "staff"
+)
+
+// Channels lists every channel the bot posts to.
+//
+//nolint:gochecknoglobals // Fixed list of channels.
+var Channels = []Channel{GamesChannel, LeaderboardChannel, RegisteredPlayersChannel, StaffChannel}
+
+// DefaultModeratorRoleName is the role that can use the moderator commands until moderator roles are configured.
+const DefaultModeratorRoleName = "Moderator"
+
+// DefaultRateLimit is the window of the rate limited commands that don't declare their own window.
+const DefaultRateLimit = time.Hour
+
+// GuildSettings are the settings of a Discord server.
+type GuildSettings struct {
+	GuildID string
+	// CurrentSeason is the season games are registered in, empty until the guild creates its first season
+	CurrentSeason    string
+	ChannelIDs       map[Channel]string
+	ModeratorRoleIDs []string
+	// RateLimit is the window of the rate limited commands that don't declare their own window
+	RateLimit time.Duration
+	// PublicResults posts the results of registered games to the games channel, otherwise only the member who
+	// registered the game sees them
+	PublicResults bool
+}
+
+// DefaultGuildSettings are the settings of a server that has not configured anything.
+func DefaultGuildSettings(guildID string) *GuildSettings {
+	return &GuildSettings{
+		GuildID:       guildID,
+		ChannelIDs:    make(map[Channel]string),
+		RateLimit:     DefaultRateLimit,
+		PublicResults: true,
+	}
+}
+
+func (s *GuildSettings) AddModeratorRole(roleID string) {
+	if !slices.Contains(s.ModeratorRoleIDs, roleID) {
+		s.ModeratorRoleIDs = append(s.ModeratorRoleIDs, roleID)
+	}
+}
+
+func (s *GuildSettings) RemoveModeratorRole(roleID string) {
+	s.ModeratorRoleIDs = slices.DeleteFunc(s.ModeratorRoleIDs, func(id string) bool {
+		return id == roleID
+	})
+}
+
+func (s *GuildSettings) String() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Current season: %s\n", s.CurrentSeason))
+	for _, channel := range Channels {
+		channelID := s.ChannelIDs[channel]
+		if channelID == "" {
+			sb.WriteString(fmt.Sprintf("%s channel: #%s (by name)\n", channel, channel))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%s channel: <#%s>\n", channel, channelID))
+	}
+	if len(s.ModeratorRoleIDs) == 0 {
+		sb.WriteString(fmt.Sprintf("Moderator roles: @%s (by name)\n", DefaultModeratorRoleName))
+	} else {
+		roles := make([]string, len(s.ModeratorRoleIDs))
+		for i, roleID := range s.ModeratorRoleIDs {
+			roles[i] = fmt.Sprintf("<@&%s>", roleID)
+		}
+		sb.WriteString(fmt.Sprintf("Moderator roles: %s\n", strings.Join(roles, ", ")))
+	}
+	sb.WriteString(fmt.Sprintf("Rate limit window: %s\n", s.RateLimit))
+	sb.WriteString(fmt.Sprintf("Public results: %t\n", s.PublicResults))
+	return sb.String()
+}
@@ -0,0 +1,64 @@
+package model
+
+import "fmt"
+
+// maxHeadToHeadGames keeps the record within the length of a Discord message.
+const maxHeadToHeadGames = 25
+
+// HeadToHead is the record of player A against player B, margins and Elo are from player A's point of view.
+type HeadToHead struct {
+	PlayerA string
+	PlayerB string
+	Games   []*HeadToHeadGame
+	WinsA   int
+	WinsB   int
+	Draws   int
+	NetElo  int
+}
+
+type HeadToHeadGame struct {
+	GameID       string
+	SeasonName   string
+	ScoreA       int
+	ScoreB       int
+	EloExchanged int
+}
+
+// Margin is the number of points player A finished ahead of player B.
+func (g *HeadToHeadGame) Margin() int {
+	return g.ScoreA - g.ScoreB
+}
+
+func (h *HeadToHead) String() string {
+	var output string
+	output += fmt.Sprintf("%s vs %s\n", h.PlayerA, h.PlayerB)
+	output += fmt.Sprintf("W/L/D: %d/%d/%d, net Elo: %+d\n\n", h.WinsA, h.WinsB, h.Draws, h.NetElo)
+	output += fmt.Sprintf("%-10s %-20s %7s %6s %4s\n", "Game", "Ahead", "Score", "Margin", "Elo")
+	output += fmt.Sprintf("%s\n", "---------------------------------------------------")
+	games := h.Games
+	if len(games) > maxHeadToHeadGames {
+		games = games[len(games)-maxHeadToHeadGames:]
+	}
+	for _, game := range games {
+		ahead := "Draw"
+		switch {
+		case game.ScoreA > game.ScoreB:
+			ahead = truncateString(h.PlayerA, 20)
+		case game.ScoreA < game.ScoreB:
+			ahead = truncateString(h.PlayerB, 20)
+		}
+		output += fmt.Sprintf(
+			"%-10s %-20s %3d-%-3d %+6d %+4d\n",
+			game.GameID,
+			ahead,
+			game.ScoreA,
+			game.ScoreB,
+			game.Margin(),
+			game.EloExchanged,
+		)
+	}
+	if len(games) < len(h.Games) {
+		output += fmt.Sprintf("\nShowing the last %d of %d games\n", len(games), len(h.Games))
+	}
+	return output
+}
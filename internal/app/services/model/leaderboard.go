@@ -3,13 +3,24 @@ package model
 import "fmt"
 
 type Leaderboard struct {
-	Entries []*LeaderboardEntry
+	Entries     []*LeaderboardEntry
+	Unqualified []*LeaderboardEntry
+	MinGames    int
 }
 
 type LeaderboardEntry struct {
+	PlayerID    int
 	PlayerName  string
 	Elo         int
 	GamesPlayed int
+	GamesNeeded int
+}
+
+// AllEntries returns the qualified entries followed by the unqualified entries.
+func (l *Leaderboard) AllEntries() []*LeaderboardEntry {
+	entries := make([]*LeaderboardEntry, 0, len(l.Entries)+len(l.Unqualified))
+	entries = append(entries, l.Entries...)
+	return append(entries, l.Unqualified...)
 }
 
 func (l *Leaderboard) String() string {
@@ -22,6 +33,16 @@ func (l *Leaderboard) String() string {
 		playerNameTruncated := truncateString(entry.PlayerName, 20)
 		output += fmt.Sprintf("%-4d %-20s %4d %12d\n", index+1, playerNameTruncated, entry.Elo, entry.GamesPlayed)
 	}
+	if len(l.Unqualified) > 0 {
+		output += "\n"
+		output += fmt.Sprintf("Unqualified (minimum %d games)\n", l.MinGames)
+		output += fmt.Sprintf("%-4s %-20s %4s %12s\n", "", "Player Name", "Elo", "Games Needed")
+		output += fmt.Sprintf("%s\n", "-------------------------------------------")
+		for _, entry := range l.Unqualified {
+			playerNameTruncated := truncateString(entry.PlayerName, 20)
+			output += fmt.Sprintf("%-4s %-20s %4d %12d\n", "-", playerNameTruncated, entry.Elo, entry.GamesNeeded)
+		}
+	}
 	return output
 }
 
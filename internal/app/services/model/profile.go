@@ -0,0 +1,26 @@
+package model
+
+// PlayerProfile holds the statistics of a player in the current season.
+type PlayerProfile struct {
+	PlayerName  string
+	SeasonName  string
+	Elo         int
+	Rank        int // 0 when the player hasn't qualified for the standings
+	GamesPlayed int
+	// PositionCounts holds how often the player finished at each position, index 0 is first place
+	PositionCounts  []int
+	AverageScore    float64
+	BestScore       int
+	BiggestEloSwing int
+	// CurrentStreak is the number of consecutive wins, or consecutive games without a win when StreakIsWin is false
+	CurrentStreak int
+	StreakIsWin   bool
+	RecentGames   []*ProfileGame
+}
+
+type ProfileGame struct {
+	GameID    string
+	Position  int
+	Score     int
+	EloChange int
+}
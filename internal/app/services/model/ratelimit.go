@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// RateLimit allows a member a number of uses of a command per window, a limit without uses or window is unlimited.
+type RateLimit struct {
+	Uses   int
+	Window time.Duration
+}
+
+func (r RateLimit) Unlimited() bool {
+	return r.Uses <= 0 || r.Window <= 0
+}
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// JobStatus is the state of a registration job.
+type JobStatus string
+
+const (
+	// JobQueued jobs wait for the worker.
+	JobQueued JobStatus = "queued"
+	// JobScraping jobs are loaded from BGA, validated and rated by the worker.
+	JobScraping JobStatus = "scraping"
+	// JobRated jobs registered their game.
+	JobRated JobStatus = "rated"
+	// JobFailed jobs could not register their game, a moderator can queue them again.
+	JobFailed JobStatus = "failed"
+)
+
+// RegistrationJob is a game a member asked to register.
+type RegistrationJob struct {
+	ID        int
+	GuildID   string
+	GameLink  string
+	UserID    string
+	ChannelID string
+	// AppID and InteractionToken identify the response of the command that queued or retried the job
+	AppID            string
+	InteractionToken string
+	Status           JobStatus
+	Attempts         int
+	Error            string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
@@ -0,0 +1,42 @@
+package model
+
+import "fmt"
+
+type SeasonReport struct {
+	SeasonName       string
+	Standings        *Leaderboard
+	MostGamesPlayed  *SeasonHighlight
+	BiggestEloGain   *SeasonHighlight
+	HighestScore     *SeasonHighlight
+	LongestWinStreak *SeasonHighlight
+	MostImproved     *SeasonHighlight
+}
+
+// SeasonHighlight is a single record of a season, GameID is set when the record was set in a specific game.
+type SeasonHighlight struct {
+	PlayerName string
+	Value      int
+	GameID     string
+}
+
+func (s *SeasonReport) String() string {
+	var output string
+	output += fmt.Sprintf("Season report: %s\n\n", s.SeasonName)
+	output += s.Standings.String()
+	output += "\n"
+	output += fmt.Sprintf("%s\n", "Highlights")
+	output += fmt.Sprintf("%s\n", "-------------------------------------------")
+	output += formatHighlight("Most games played", s.MostGamesPlayed, "games")
+	output += formatHighlight("Biggest Elo gain", s.BiggestEloGain, "Elo")
+	output += formatHighlight("Highest score", s.HighestScore, "VP")
+	output += formatHighlight("Longest win streak", s.LongestWinStreak, "wins")
+	output += formatHighlight("Most improved", s.MostImproved, "Elo")
+	return output
+}
+
+func formatHighlight(title string, highlight *SeasonHighlight, unit string) string {
+	if highlight == nil {
+		return fmt.Sprintf("%-20s %s\n", title, "-")
+	}
+	return fmt.Sprintf("%-20s %s (%d %s)\n", title, highlight.PlayerName, highlight.Value, unit)
+}
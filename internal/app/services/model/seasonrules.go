@@ -0,0 +1,51 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SeasonRules are the rules a season is rated by, they are fixed when the season is created.
+type SeasonRules struct {
+	SeasonName                string
+	KFactor                   int
+	StartElo                  int
+	EloFloor                  int
+	MinPlayers                int
+	MaxGameAgeDays            int
+	AllowedFanFactionSettings []FanFactionSetting
+}
+
+// DefaultSeasonRules are the rules the first season of a league starts from, they match the rules of the first fan
+// faction season.
+func DefaultSeasonRules() *SeasonRules {
+	return &SeasonRules{
+		KFactor:                   64,
+		StartElo:                  1000,
+		EloFloor:                  0,
+		MinPlayers:                2,
+		MaxGameAgeDays:            60,
+		AllowedFanFactionSettings: []FanFactionSetting{On, OnNoFireAndIce},
+	}
+}
+
+func (r *SeasonRules) AllowsFanFactionSetting(setting FanFactionSetting) bool {
+	return slices.Contains(r.AllowedFanFactionSettings, setting)
+}
+
+func (r *SeasonRules) String() string {
+	settings := make([]string, len(r.AllowedFanFactionSettings))
+	for i, setting := range r.AllowedFanFactionSettings {
+		settings[i] = setting.String()
+	}
+	var output string
+	output += fmt.Sprintf("%-20s %s\n", "Season", r.SeasonName)
+	output += fmt.Sprintf("%-20s %d\n", "K factor", r.KFactor)
+	output += fmt.Sprintf("%-20s %d\n", "Start Elo", r.StartElo)
+	output += fmt.Sprintf("%-20s %d\n", "Elo floor", r.EloFloor)
+	output += fmt.Sprintf("%-20s %d\n", "Minimum players", r.MinPlayers)
+	output += fmt.Sprintf("%-20s %d days\n", "Max game age", r.MaxGameAgeDays)
+	output += fmt.Sprintf("%-20s %s\n", "Fan factions", strings.Join(settings, ", "))
+	return output
+}
@@ -3,14 +3,12 @@ package services
 import "github.com/playwright-community/playwright-go"
 
 type Pages struct {
-	browser        playwright.Browser
-	maxGameAgeDays int
+	browser playwright.Browser
 }
 
-func NewPages(browser playwright.Browser, maxGameAgeDays int) *Pages {
+func NewPages(browser playwright.Browser) *Pages {
 	return &Pages{
-		browser:        browser,
-		maxGameAgeDays: maxGameAgeDays,
+		browser: browser,
 	}
 }
 
@@ -19,7 +17,15 @@ func (p *Pages) NewGameScraper() (*GameScraper, error) {
 	if err != nil {
 		return nil, err
 	}
-	return newGameScraper(page, p.maxGameAgeDays), nil
+	return newGameScraper(page), nil
+}
+
+func (p *Pages) NewProfileScraper() (*ProfileScraper, error) {
+	page, err := p.browser.NewPage()
+	if err != nil {
+		return nil, err
+	}
+	return newProfileScraper(page), nil
 }
 
 func (p *Pages) Close() error {
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"tmff-discord-app/internal/app/repository"
+	repomodel "tmff-discord-app/internal/app/repository/model"
+
+	"github.com/pkg/errors"
+)
+
+type Player struct {
+	playerRepo *repository.Player
+	gameRepo   *repository.Game
+	seasonRepo *repository.Season
+}
+
+func NewPlayer(playerRepo *repository.Player, gameRepo *repository.Game, seasonRepo *repository.Season) *Player {
+	return &Player{
+		playerRepo: playerRepo,
+		gameRepo:   gameRepo,
+		seasonRepo: seasonRepo,
+	}
+}
+
+// RequestLink asks to link the Discord account to the player, a moderator has to approve the link before it is used.
+func (p *Player) RequestLink(playerName, discordID string) (*repomodel.Player, error) {
+	_, err := p.playerRepo.GetPlayerByDiscordID(discordID)
+	if err == nil {
+		return nil, repository.ErrDiscordAlreadyLinked
+	}
+	if !errors.Is(err, repository.ErrPlayerNotFound) {
+		return nil, errors.Wrap(err, "failed to get linked player")
+	}
+
+	player, err := p.playerRepo.GetPlayer(playerName)
+	if err != nil {
+		return nil, err
+	}
+	if player.DiscordID != nil {
+		return nil, repository.ErrPlayerAlreadyLinked
+	}
+
+	err = p.playerRepo.RequestLink(player.ID, discordID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to request link")
+	}
+	return player, nil
+}
+
+func (p *Player) ApproveLink(discordID string) (*repomodel.Player, error) {
+	return p.playerRepo.ApproveLink(discordID)
+}
+
+// GetLinkedPlayer returns the player linked to the Discord account.
+func (p *Player) GetLinkedPlayer(discordID string) (*repomodel.Player, error) {
+	player, err := p.playerRepo.GetPlayerByDiscordID(discordID)
+	if errors.Is(err, repository.ErrPlayerNotFound) {
+		return nil, errors.New("your Discord account is not linked to a player, use /link first")
+	}
+	return player, err
+}
+
+// RequestRegistration queues a player registration for a moderator to approve.
+func (p *Player) RequestRegistration(name, bgaID, discordID string) (*repomodel.RegistrationRequest, error) {
+	_, err := p.playerRepo.GetPlayerByDiscordID(discordID)
+	if err == nil {
+		return nil, repository.ErrDiscordAlreadyLinked
+	}
+	if !errors.Is(err, repository.ErrPlayerNotFound) {
+		return nil, errors.Wrap(err, "failed to get linked player")
+	}
+
+	_, err = p.playerRepo.GetPlayer(name)
+	if err == nil {
+		return nil, errors.New("a player with this name is already registered, use /link instead")
+	}
+	if !errors.Is(err, repository.ErrPlayerNotFound) {
+		return nil, errors.Wrap(err, "failed to get player")
+	}
+
+	return p.playerRepo.CreateRegistrationRequest(name, bgaID, discordID)
+}
+
+func (p *Player) ApproveRegistration(requestID int, reviewerID string) (*repomodel.Player, error) {
+	return p.playerRepo.ApproveRegistrationRequest(requestID, reviewerID)
+}
+
+// CancelRegistration removes a pending registration that never reached the moderators.
+func (p *Player) CancelRegistration(requestID int) error {
+	return p.playerRepo.DeleteRegistrationRequest(requestID)
+}
+
+func (p *Player) RejectRegistration(requestID int, reviewerID string) (*repomodel.RegistrationRequest, error) {
+	return p.playerRepo.RejectRegistrationRequest(requestID, reviewerID)
+}
+
+func (p *Player) RenamePlayer(name, newName string) error {
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return errors.New("new name is required")
+	}
+	return p.playerRepo.RenamePlayer(name, newName)
+}
+
+func (p *Player) SetBGAID(name, bgaID string) error {
+	bgaID = strings.TrimSpace(bgaID)
+	if bgaID == "" {
+		return errors.New("BGA ID is required")
+	}
+	return p.playerRepo.SetBGAID(name, bgaID)
+}
+
+func (p *Player) DeactivatePlayer(name string) error {
+	return p.playerRepo.DeactivatePlayer(name)
+}
+
+// AnonymizePlayer forgets the player, the returned player carries the pseudonym the player's games are shown under.
+func (p *Player) AnonymizePlayer(name string) (*repomodel.Player, error) {
+	return p.playerRepo.AnonymizePlayer(name)
+}
+
+// MergePlayers merges the source player into the target player. The ratings of every season the source played in
+// are recalculated by replaying the season's games, as the merged history changes the Elo of the target and of every
+// later opponent.
+func (p *Player) MergePlayers(sourceName, targetName string) error {
+	source, err := p.playerRepo.GetPlayer(sourceName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get player %s", sourceName)
+	}
+	target, err := p.playerRepo.GetPlayer(targetName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get player %s", targetName)
+	}
+	if source.ID == target.ID {
+		return errors.New("a player can't be merged into itself")
+	}
+
+	participants, err := p.gameRepo.GetAllGameParticipants()
+	if err != nil {
+		return errors.Wrap(err, "failed to get game participants")
+	}
+	allRules, err := p.seasonRepo.GetAllRules()
+	if err != nil {
+		return errors.Wrap(err, "failed to get season rules")
+	}
+	rulesBySeason := make(map[string]*repomodel.SeasonRules)
+	for _, rules := range allRules {
+		rulesBySeason[rules.SeasonName] = rules
+	}
+
+	affectedSeasons := make(map[string][][]*repomodel.GameParticipant)
+	for _, game := range groupByGame(participants) {
+		playedBySource, playedByTarget := false, false
+		for _, participant := range game {
+			playedBySource = playedBySource || participant.PlayerID == source.ID
+			playedByTarget = playedByTarget || participant.PlayerID == target.ID
+		}
+		if playedBySource && playedByTarget {
+			return errors.Wrapf(repository.ErrPlayersInSameGame, "game %s", game[0].GameID)
+		}
+		if playedBySource {
+			affectedSeasons[game[0].SeasonName] = nil
+		}
+	}
+	for _, game := range groupByGame(participants) {
+		if _, ok := affectedSeasons[game[0].SeasonName]; ok {
+			affectedSeasons[game[0].SeasonName] = append(affectedSeasons[game[0].SeasonName], game)
+		}
+	}
+
+	var gameParticipants []*repomodel.GameParticipant
+	var seasonParticipants []*repomodel.SeasonParticipant
+	for seasonName, games := range affectedSeasons {
+		rules, ok := rulesBySeason[seasonName]
+		if !ok {
+			return fmt.Errorf("no rules found for season %s", seasonName)
+		}
+		for _, game := range games {
+			for _, participant := range game {
+				if participant.PlayerID == source.ID {
+					participant.PlayerID = target.ID
+				}
+				gameParticipants = append(gameParticipants, participant)
+			}
+		}
+		seasonParticipants = append(seasonParticipants, replaySeason(seasonName, games, rules)...)
+	}
+
+	return p.playerRepo.MergePlayers(source, target, gameParticipants, seasonParticipants)
+}
+
+// replaySeason rates the games of a season again in the order they were registered. The Elo of every game
+// participant is updated in place and the resulting standings of the season are returned.
+func replaySeason(
+	seasonName string,
+	games [][]*repomodel.GameParticipant,
+	rules *repomodel.SeasonRules,
+) []*repomodel.SeasonParticipant {
+	// Every game is rated by the rules of the season, so the lookup can't fail
+	ratings, _ := replayGames(games, func(string) (*repomodel.SeasonRules, error) {
+		return rules, nil
+	})
+	gamesPlayed := make(map[int]int)
+	for _, game := range games {
+		for _, participant := range game {
+			gamesPlayed[participant.PlayerID]++
+		}
+	}
+
+	seasonParticipants := make([]*repomodel.SeasonParticipant, 0, len(ratings))
+	for playerID, elo := range ratings {
+		seasonParticipants = append(seasonParticipants, &repomodel.SeasonParticipant{
+			SeasonName:  seasonName,
+			PlayerID:    playerID,
+			Elo:         elo,
+			GamesPlayed: gamesPlayed[playerID],
+		})
+	}
+	return seasonParticipants
+}
+
+// AddAccount lets the player play from an additional BGA account, games of the account are rated for the player.
+func (p *Player) AddAccount(playerName, accountName, bgaID string) error {
+	player, err := p.playerRepo.GetPlayer(playerName)
+	if err != nil {
+		return err
+	}
+	accountName = strings.TrimSpace(accountName)
+	bgaID = strings.TrimSpace(bgaID)
+	if accountName == "" || bgaID == "" {
+		return errors.New("account name and BGA ID are required")
+	}
+	return p.playerRepo.AddAccount(player.ID, accountName, bgaID)
+}
+
+func (p *Player) RemoveAccount(accountName string) error {
+	return p.playerRepo.RemoveAccount(accountName)
+}
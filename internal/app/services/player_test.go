@@ -0,0 +1,436 @@
+package services_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+	"tmff-discord-app/internal/app/repository"
+	"tmff-discord-app/internal/app/services"
+	"tmff-discord-app/internal/app/services/model"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayerLink(t *testing.T) {
+	t.Parallel()
+	t.Run("Test linked players are mentioned in game results", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerService := services.NewPlayer(playerRepo, gameRepo, seasonRepo)
+		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
+
+		err := playerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 2", "2")
+		require.NoError(t, err)
+
+		_, err = playerService.GetLinkedPlayer("discord-1")
+		require.Error(t, err)
+
+		player, err := playerService.RequestLink("Player 1", "discord-1")
+		require.NoError(t, err)
+		assert.Equal(t, "Player 1", player.Name)
+		_, err = playerService.ApproveLink("discord-1")
+		require.NoError(t, err)
+
+		player, err = playerService.GetLinkedPlayer("discord-1")
+		require.NoError(t, err)
+		assert.Equal(t, "Player 1", player.Name)
+
+		currentTime := time.Now()
+		results, err := gameService.RegisterGame(&model.GameOutcome{
+			ID: "1",
+			Players: []*model.PlayerResult{
+				{Name: "Player 1", Score: 150},
+				{Name: "Player 2", Score: 100},
+			},
+			FanFactionSetting: model.On,
+			CreationTime:      &currentTime,
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "Player 1", results[0].Name)
+		assert.Equal(t, "discord-1", results[0].DiscordID)
+		assert.Equal(t, "Player 2", results[1].Name)
+		assert.Empty(t, results[1].DiscordID)
+	})
+
+	t.Run("Test link is rejected when already linked", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerService := services.NewPlayer(playerRepo, gameRepo, seasonRepo)
+
+		err := playerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 2", "2")
+		require.NoError(t, err)
+
+		_, err = playerService.RequestLink("Player 1", "discord-1")
+		require.NoError(t, err)
+		_, err = playerService.ApproveLink("discord-1")
+		require.NoError(t, err)
+
+		_, err = playerService.RequestLink("Player 2", "discord-1")
+		assert.True(t, errors.Is(err, repository.ErrDiscordAlreadyLinked))
+		_, err = playerService.RequestLink("Player 1", "discord-2")
+		assert.True(t, errors.Is(err, repository.ErrPlayerAlreadyLinked))
+		_, err = playerService.RequestLink("Player 3", "discord-3")
+		assert.True(t, errors.Is(err, repository.ErrPlayerNotFound))
+	})
+}
+
+func TestRequestRegistration(t *testing.T) {
+	t.Parallel()
+	t.Run("Test registration of a new player", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerService := services.NewPlayer(playerRepo, gameRepo, seasonRepo)
+
+		request, err := playerService.RequestRegistration("Player 1", "1", "discord-1")
+		require.NoError(t, err)
+		player, err := playerService.ApproveRegistration(request.ID, "moderator")
+		require.NoError(t, err)
+		assert.Equal(t, "Player 1", player.Name)
+
+		player, err = playerService.GetLinkedPlayer("discord-1")
+		require.NoError(t, err)
+		assert.Equal(t, "Player 1", player.Name)
+
+		_, err = playerService.RequestRegistration("Player 2", "2", "discord-1")
+		assert.True(t, errors.Is(err, repository.ErrDiscordAlreadyLinked))
+		_, err = playerService.RequestRegistration("Player 1", "1", "discord-2")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already registered")
+	})
+}
+
+func TestPlayerMaintenance(t *testing.T) {
+	t.Parallel()
+	t.Run("Test merge players recalculates ratings", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerService := services.NewPlayer(playerRepo, gameRepo, seasonRepo)
+		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
+
+		for i, name := range []string{"Player 1", "Player 2", "Player 3", "Player One"} {
+			err := playerRepo.InsertPlayer(name, strconv.Itoa(i+1))
+			require.NoError(t, err)
+		}
+
+		currentTime := time.Now()
+		_, err := gameService.RegisterGame(&model.GameOutcome{
+			ID: "1",
+			Players: []*model.PlayerResult{
+				{Name: "Player 1", Score: 150},
+				{Name: "Player 2", Score: 100},
+			},
+			FanFactionSetting: model.On,
+			CreationTime:      &currentTime,
+		})
+		require.NoError(t, err)
+		_, err = gameService.RegisterGame(&model.GameOutcome{
+			ID: "2",
+			Players: []*model.PlayerResult{
+				{Name: "Player One", Score: 150},
+				{Name: "Player 3", Score: 100},
+			},
+			FanFactionSetting: model.On,
+			CreationTime:      &currentTime,
+		})
+		require.NoError(t, err)
+
+		err = playerService.MergePlayers("Player One", "Player 1")
+		require.NoError(t, err)
+
+		_, err = playerRepo.GetPlayer("Player One")
+		assert.True(t, errors.Is(err, repository.ErrPlayerNotFound))
+		player, err := playerRepo.GetPlayer("Player 1")
+		require.NoError(t, err)
+
+		participants, err := seasonRepo.GetAll()
+		require.NoError(t, err)
+		require.Len(t, participants, 3)
+		assert.Equal(t, player.ID, participants[0].PlayerID)
+		assert.Equal(t, 1021, participants[0].Elo)
+		assert.Equal(t, 2, participants[0].GamesPlayed)
+		assert.Equal(t, 990, participants[1].Elo)
+		assert.Equal(t, 989, participants[2].Elo)
+
+		game, err := gameRepo.GetGameWithParticipants("2")
+		require.NoError(t, err)
+		for _, participant := range game.Participants {
+			if participant.PlayerID == player.ID {
+				assert.Equal(t, 1011, participant.EloBefore)
+				assert.Equal(t, 10, participant.EloChange)
+			} else {
+				assert.Equal(t, 1000, participant.EloBefore)
+				assert.Equal(t, -10, participant.EloChange)
+			}
+		}
+	})
+
+	t.Run("Test merge players ranks ended seasons again", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerService := services.NewPlayer(playerRepo, gameRepo, seasonRepo)
+		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
+		leaderboardService := services.NewLeaderboard(seasonRepo, playerRepo, gameRepo)
+		seasonReportService := services.NewSeasonReport(seasonRepo, gameRepo, playerRepo, leaderboardService)
+
+		for i, name := range []string{"Player 1", "Player 2", "Player 3", "Player One"} {
+			err := playerRepo.InsertPlayer(name, strconv.Itoa(i+1))
+			require.NoError(t, err)
+		}
+		err := seasonRepo.SetMinGames(2)
+		require.NoError(t, err)
+
+		currentTime := time.Now()
+		for i, players := range [][]string{{"Player 1", "Player 2"}, {"Player One", "Player 3"}, {"Player 2", "Player 3"}} {
+			_, err = gameService.RegisterGame(&model.GameOutcome{
+				ID: strconv.Itoa(i + 1),
+				Players: []*model.PlayerResult{
+					{Name: players[0], Score: 150},
+					{Name: players[1], Score: 100},
+				},
+				FanFactionSetting: model.On,
+				CreationTime:      &currentTime,
+			})
+			require.NoError(t, err)
+		}
+		// Player 1 and Player One haven't played enough games to be ranked on their own
+		_, err = seasonReportService.CloseSeason()
+		require.NoError(t, err)
+
+		err = playerService.MergePlayers("Player One", "Player 1")
+		require.NoError(t, err)
+
+		player, err := playerRepo.GetPlayer("Player 1")
+		require.NoError(t, err)
+		participants, err := seasonRepo.GetAll()
+		require.NoError(t, err)
+		require.Len(t, participants, 3)
+		assert.Equal(t, player.ID, participants[0].PlayerID)
+		for i, participant := range participants {
+			require.NotNil(t, participant.FinalRank)
+			assert.Equal(t, i+1, *participant.FinalRank)
+		}
+	})
+
+	t.Run("Test merge players of the same game", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerService := services.NewPlayer(playerRepo, gameRepo, seasonRepo)
+		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
+
+		err := playerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 2", "2")
+		require.NoError(t, err)
+
+		currentTime := time.Now()
+		_, err = gameService.RegisterGame(&model.GameOutcome{
+			ID: "1",
+			Players: []*model.PlayerResult{
+				{Name: "Player 1", Score: 150},
+				{Name: "Player 2", Score: 100},
+			},
+			FanFactionSetting: model.On,
+			CreationTime:      &currentTime,
+		})
+		require.NoError(t, err)
+
+		err = playerService.MergePlayers("Player 2", "Player 1")
+		assert.True(t, errors.Is(err, repository.ErrPlayersInSameGame))
+		err = playerService.MergePlayers("Player 1", "Player 1")
+		require.Error(t, err)
+	})
+
+	t.Run("Test deactivated players are not rated", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerService := services.NewPlayer(playerRepo, gameRepo, seasonRepo)
+		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
+
+		err := playerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 2", "2")
+		require.NoError(t, err)
+		err = playerService.DeactivatePlayer("Player 2")
+		require.NoError(t, err)
+
+		currentTime := time.Now()
+		_, err = gameService.RegisterGame(&model.GameOutcome{
+			ID: "1",
+			Players: []*model.PlayerResult{
+				{Name: "Player 1", Score: 150},
+				{Name: "Player 2", Score: 100},
+			},
+			FanFactionSetting: model.On,
+			CreationTime:      &currentTime,
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "less than 2 registered players")
+	})
+}
+
+func TestPlayerAccounts(t *testing.T) {
+	t.Parallel()
+	t.Run("Test games of an additional account are rated for the player", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerService := services.NewPlayer(playerRepo, gameRepo, seasonRepo)
+		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
+
+		err := playerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 2", "2")
+		require.NoError(t, err)
+		err = playerService.AddAccount("Player 1", "Player 1 Alt", "11")
+		require.NoError(t, err)
+
+		currentTime := time.Now()
+		_, err = gameService.RegisterGame(&model.GameOutcome{
+			ID: "1",
+			Players: []*model.PlayerResult{
+				{Name: "Player 1 Alt", Score: 150},
+				{Name: "Player 2", Score: 100},
+			},
+			FanFactionSetting: model.On,
+			CreationTime:      &currentTime,
+		})
+		require.NoError(t, err)
+
+		player, err := playerRepo.GetPlayer("Player 1")
+		require.NoError(t, err)
+		participant, err := seasonRepo.GetSeasonParticipant(player.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1011, participant.Elo)
+
+		_, err = gameService.RegisterGame(&model.GameOutcome{
+			ID: "2",
+			Players: []*model.PlayerResult{
+				{Name: "Player 1 Alt", Score: 150},
+				{Name: "Player 1", Score: 120},
+				{Name: "Player 2", Score: 100},
+			},
+			FanFactionSetting: model.On,
+			CreationTime:      &currentTime,
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "both accounts of Player 1")
+	})
+
+	t.Run("Test merged player becomes an additional account", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerService := services.NewPlayer(playerRepo, gameRepo, seasonRepo)
+
+		err := playerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player One", "11")
+		require.NoError(t, err)
+
+		err = playerService.MergePlayers("Player One", "Player 1")
+		require.NoError(t, err)
+
+		player, err := playerRepo.GetPlayerByAccountName("Player One")
+		require.NoError(t, err)
+		assert.Equal(t, "Player 1", player.Name)
+	})
+}
+
+func TestAnonymizePlayer(t *testing.T) {
+	t.Parallel()
+	dbx := newMigratedSQLiteDB(t)
+	queryTimeout := 2 * time.Second
+	playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+	seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+	gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+	playerService := services.NewPlayer(playerRepo, gameRepo, seasonRepo)
+	gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
+
+	err := playerRepo.InsertPlayer("Player 1", "1")
+	require.NoError(t, err)
+	err = playerRepo.InsertPlayer("Player 2", "2")
+	require.NoError(t, err)
+	err = playerService.AddAccount("Player 1", "Player 1 Alt", "11")
+	require.NoError(t, err)
+	_, err = playerService.RequestLink("Player 1", "discord-1")
+	require.NoError(t, err)
+	_, err = playerService.ApproveLink("discord-1")
+	require.NoError(t, err)
+
+	currentTime := time.Now()
+	_, err = gameService.RegisterGame(&model.GameOutcome{
+		ID: "1",
+		Players: []*model.PlayerResult{
+			{Name: "Player 1", Score: 150},
+			{Name: "Player 2", Score: 100},
+		},
+		FanFactionSetting: model.On,
+		CreationTime:      &currentTime,
+	})
+	require.NoError(t, err)
+
+	player, err := playerService.AnonymizePlayer("Player 1")
+	require.NoError(t, err)
+	assert.Equal(t, "Anonymous Player "+strconv.Itoa(player.ID), player.Name)
+	assert.Nil(t, player.DiscordID)
+	assert.False(t, player.Active)
+
+	_, err = playerRepo.GetPlayerByAccountName("Player 1")
+	assert.True(t, errors.Is(err, repository.ErrPlayerNotFound))
+	_, err = playerRepo.GetPlayerByAccountName("Player 1 Alt")
+	assert.True(t, errors.Is(err, repository.ErrPlayerNotFound))
+	_, err = playerRepo.GetPlayerByDiscordID("discord-1")
+	assert.True(t, errors.Is(err, repository.ErrPlayerNotFound))
+
+	// The game and the ratings are untouched
+	game, err := gameRepo.GetGameWithParticipants("1")
+	require.NoError(t, err)
+	require.Len(t, game.Participants, 2)
+	participants, err := seasonRepo.GetAll()
+	require.NoError(t, err)
+	require.Len(t, participants, 2)
+	assert.Equal(t, player.ID, participants[0].PlayerID)
+	assert.Equal(t, 1011, participants[0].Elo)
+	assert.Equal(t, 989, participants[1].Elo)
+}
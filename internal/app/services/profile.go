@@ -0,0 +1,124 @@
+package services
+
+import (
+	"tmff-discord-app/internal/app/repository"
+	"tmff-discord-app/internal/app/services/model"
+
+	"github.com/pkg/errors"
+)
+
+const recentGamesInProfile = 5
+
+type Profile struct {
+	playerRepo         *repository.Player
+	seasonRepo         *repository.Season
+	gameRepo           *repository.Game
+	leaderboardService *Leaderboard
+}
+
+func NewProfile(
+	playerRepo *repository.Player,
+	seasonRepo *repository.Season,
+	gameRepo *repository.Game,
+	leaderboardService *Leaderboard,
+) *Profile {
+	return &Profile{
+		playerRepo:         playerRepo,
+		seasonRepo:         seasonRepo,
+		gameRepo:           gameRepo,
+		leaderboardService: leaderboardService,
+	}
+}
+
+// GetProfile returns the statistics of the player in the current season.
+func (p *Profile) GetProfile(playerName string) (*model.PlayerProfile, error) {
+	player, err := p.playerRepo.GetPlayer(playerName)
+	if err != nil {
+		return nil, err
+	}
+
+	seasonParticipant, err := p.seasonRepo.GetSeasonParticipant(player.ID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "no profile for %s", player.Name)
+	}
+
+	standings, err := p.leaderboardService.GetLeaderboard()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get standings")
+	}
+
+	participants, err := p.gameRepo.GetPlayerSeasonGameParticipants(player.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get games")
+	}
+
+	profile := &model.PlayerProfile{
+		PlayerName:  player.Name,
+		SeasonName:  seasonParticipant.SeasonName,
+		Elo:         seasonParticipant.Elo,
+		GamesPlayed: seasonParticipant.GamesPlayed,
+	}
+	for i, entry := range standings.Entries {
+		if entry.PlayerID == player.ID {
+			profile.Rank = i + 1
+			break
+		}
+	}
+
+	var games []*model.ProfileGame
+	for _, game := range groupByGame(participants) {
+		for _, participant := range game {
+			if participant.PlayerID != player.ID {
+				continue
+			}
+			games = append(games, &model.ProfileGame{
+				GameID:    participant.GameID,
+				Position:  finishingPosition(participant, game),
+				Score:     participant.Score,
+				EloChange: participant.EloChange,
+			})
+		}
+	}
+	addGameStatistics(profile, games)
+	return profile, nil
+}
+
+// addGameStatistics derives the statistics of the profile from the player's games, ordered by registration.
+func addGameStatistics(profile *model.PlayerProfile, games []*model.ProfileGame) {
+	if len(games) == 0 {
+		return
+	}
+
+	scoreSum := 0
+	for _, game := range games {
+		for len(profile.PositionCounts) < game.Position {
+			profile.PositionCounts = append(profile.PositionCounts, 0)
+		}
+		profile.PositionCounts[game.Position-1]++
+		scoreSum += game.Score
+		profile.BestScore = max(profile.BestScore, game.Score)
+		if abs(game.EloChange) > abs(profile.BiggestEloSwing) {
+			profile.BiggestEloSwing = game.EloChange
+		}
+	}
+	profile.AverageScore = float64(scoreSum) / float64(len(games))
+
+	profile.StreakIsWin = games[len(games)-1].Position == 1
+	for i := len(games) - 1; i >= 0; i-- {
+		if (games[i].Position == 1) != profile.StreakIsWin {
+			break
+		}
+		profile.CurrentStreak++
+	}
+
+	for i := len(games) - 1; i >= 0 && len(profile.RecentGames) < recentGamesInProfile; i-- {
+		profile.RecentGames = append(profile.RecentGames, games[i])
+	}
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,99 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+	"tmff-discord-app/internal/app/repository"
+	"tmff-discord-app/internal/app/services"
+	"tmff-discord-app/internal/app/services/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetProfile(t *testing.T) {
+	t.Parallel()
+	t.Run("Test profile statistics", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
+		leaderboardService := services.NewLeaderboard(seasonRepo, playerRepo, gameRepo)
+		profileService := services.NewProfile(playerRepo, seasonRepo, gameRepo, leaderboardService)
+
+		err := playerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 2", "2")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 3", "3")
+		require.NoError(t, err)
+
+		currentTime := time.Now()
+		games := [][]*model.PlayerResult{
+			{{Name: "Player 1", Score: 150}, {Name: "Player 2", Score: 100}},
+			{{Name: "Player 1", Score: 90}, {Name: "Player 2", Score: 120}},
+			{{Name: "Player 2", Score: 110}, {Name: "Player 3", Score: 100}},
+			{{Name: "Player 1", Score: 120}, {Name: "Player 3", Score: 80}},
+		}
+		for i, players := range games {
+			_, err = gameService.RegisterGame(&model.GameOutcome{
+				ID:                string(rune('1' + i)),
+				Players:           players,
+				FanFactionSetting: model.On,
+				CreationTime:      &currentTime,
+			})
+			require.NoError(t, err)
+		}
+
+		profile, err := profileService.GetProfile("Player 1")
+		require.NoError(t, err)
+		assert.Equal(t, "Player 1", profile.PlayerName)
+		assert.Equal(t, "First Fan Faction Season", profile.SeasonName)
+		assert.Equal(t, 3, profile.GamesPlayed)
+		assert.Equal(t, []int{2, 1}, profile.PositionCounts)
+		assert.InDelta(t, 120.0, profile.AverageScore, 0.001)
+		assert.Equal(t, 150, profile.BestScore)
+		assert.Equal(t, 1, profile.CurrentStreak)
+		assert.True(t, profile.StreakIsWin)
+		require.Len(t, profile.RecentGames, 3)
+		assert.Equal(t, "4", profile.RecentGames[0].GameID)
+		assert.Equal(t, 1, profile.RecentGames[0].Position)
+		assert.Equal(t, "2", profile.RecentGames[1].GameID)
+		assert.Equal(t, 2, profile.RecentGames[1].Position)
+
+		leaderboard, err := leaderboardService.GetLeaderboard()
+		require.NoError(t, err)
+		require.Greater(t, profile.Rank, 0)
+		assert.Equal(t, "Player 1", leaderboard.Entries[profile.Rank-1].PlayerName)
+		assert.Equal(t, leaderboard.Entries[profile.Rank-1].Elo, profile.Elo)
+	})
+
+	t.Run("Test profile of a player without games", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		leaderboardService := services.NewLeaderboard(seasonRepo, playerRepo, gameRepo)
+		profileService := services.NewProfile(playerRepo, seasonRepo, gameRepo, leaderboardService)
+		seasonService := services.NewSeason(seasonRepo, playerRepo)
+
+		err := playerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+
+		_, err = profileService.GetProfile("Player 1")
+		require.ErrorIs(t, err, repository.ErrSeasonParticipantNotFound)
+
+		_, err = seasonService.JoinSeason("Player 1")
+		require.NoError(t, err)
+		profile, err := profileService.GetProfile("Player 1")
+		require.NoError(t, err)
+		assert.Equal(t, 0, profile.GamesPlayed)
+		assert.Empty(t, profile.RecentGames)
+		assert.Equal(t, 1000, profile.Elo)
+	})
+}
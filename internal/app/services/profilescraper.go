@@ -0,0 +1,76 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"tmff-discord-app/pkg/names"
+
+	"github.com/pkg/errors"
+	"github.com/playwright-community/playwright-go"
+)
+
+var (
+	ErrProfileNotFound = errors.New("BGA profile doesn't exist")
+)
+
+type ProfileScraper struct {
+	// The profiles are loaded in one page, so commands look them up one at a time
+	lock sync.Mutex
+	page playwright.Page
+}
+
+func newProfileScraper(page playwright.Page) *ProfileScraper {
+	return &ProfileScraper{
+		page: page,
+	}
+}
+
+// GetProfileName returns the username shown on the BGA profile page of the player.
+func (ps *ProfileScraper) GetProfileName(bgaID string) (string, error) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	profileURL := fmt.Sprintf("https://boardgamearena.com/player?id=%s", bgaID)
+	if _, err := ps.page.Goto(profileURL); err != nil {
+		return "", err
+	}
+
+	// Profiles that don't exist are shown without a player name
+	nameElement := ps.page.Locator("#player_name")
+	count, err := nameElement.Count()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to find player name")
+	}
+	if count == 0 {
+		return "", ErrProfileNotFound
+	}
+	name, err := nameElement.First().TextContent()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get player name")
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", ErrProfileNotFound
+	}
+	return name, nil
+}
+
+// VerifyProfile checks that the BGA profile with the ID belongs to the player with the name.
+func (ps *ProfileScraper) VerifyProfile(name, bgaID string) error {
+	profileName, err := ps.GetProfileName(bgaID)
+	if errors.Is(err, ErrProfileNotFound) {
+		return fmt.Errorf("there is no BGA profile with ID %s", bgaID)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to load BGA profile")
+	}
+	if !names.Equal(profileName, name) {
+		return fmt.Errorf("the name doesn't match the BGA profile with ID %s, did you mean %s?", bgaID, profileName)
+	}
+	return nil
+}
+
+func (ps *ProfileScraper) Close() error {
+	return ps.page.Close()
+}
@@ -0,0 +1,88 @@
+package services_test
+
+import (
+	"net/url"
+	"path/filepath"
+	"testing"
+	"tmff-discord-app/internal/app/services"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileScraper(t *testing.T) {
+	t.Parallel()
+	t.Run("profile name", func(t *testing.T) {
+		t.Parallel()
+		profileScraper := createProfileScraper(t)
+		defer profileScraper.Close()
+
+		name, err := profileScraper.GetProfileName("84001234")
+		require.NoError(t, err)
+		assert.Equal(t, "Stahlbrötchen", name)
+	})
+
+	t.Run("profile does not exist", func(t *testing.T) {
+		t.Parallel()
+		profileScraper := createProfileScraper(t)
+		defer profileScraper.Close()
+
+		_, err := profileScraper.GetProfileName("1")
+		require.ErrorIs(t, err, services.ErrProfileNotFound)
+	})
+
+	t.Run("verify profile", func(t *testing.T) {
+		t.Parallel()
+		profileScraper := createProfileScraper(t)
+		defer profileScraper.Close()
+
+		err := profileScraper.VerifyProfile("stahlbrötchen", "84001234")
+		require.NoError(t, err)
+	})
+
+	t.Run("verify profile with another name", func(t *testing.T) {
+		t.Parallel()
+		profileScraper := createProfileScraper(t)
+		defer profileScraper.Close()
+
+		err := profileScraper.VerifyProfile("Zaarito", "84001234")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "the name doesn't match the BGA profile with ID 84001234")
+		assert.Contains(t, err.Error(), "did you mean Stahlbrötchen?")
+	})
+
+	t.Run("verify profile that does not exist", func(t *testing.T) {
+		t.Parallel()
+		profileScraper := createProfileScraper(t)
+		defer profileScraper.Close()
+
+		err := profileScraper.VerifyProfile("Zaarito", "1")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "there is no BGA profile with ID 1")
+	})
+}
+
+// createProfileScraper returns a profile scraper that is served a synthetic profile of player 84001234 instead of BGA,
+// every other ID gets the page of a profile that doesn't exist. The fixtures follow the markup the scraper expects,
+// they are not captures of real BGA pages.
+func createProfileScraper(t *testing.T) *services.ProfileScraper {
+	page, err := createBrowser(t).NewPage()
+	require.NoError(t, err)
+	err = page.Route("**/player?id=*", func(route playwright.Route) {
+		fixture := "bga_profile_not_found.html"
+		requestURL, parseErr := url.Parse(route.Request().URL())
+		if parseErr == nil && requestURL.Query().Get("id") == "84001234" {
+			fixture = "bga_profile.html"
+		}
+		fulfillErr := route.Fulfill(playwright.RouteFulfillOptions{
+			ContentType: playwright.String("text/html; charset=utf-8"),
+			Path:        playwright.String(filepath.Join("testdata", fixture)),
+		})
+		if fulfillErr != nil {
+			t.Errorf("could not serve fixture: %v", fulfillErr)
+		}
+	})
+	require.NoError(t, err)
+	return services.NewProfileScraperForPage(page)
+}
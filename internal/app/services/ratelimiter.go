@@ -0,0 +1,57 @@
+package services
+
+import (
+	"time"
+	"tmff-discord-app/internal/app/repository"
+	repomodel "tmff-discord-app/internal/app/repository/model"
+	"tmff-discord-app/internal/app/services/model"
+)
+
+// RateLimiter limits how often a member can use a command. Uses are stored so limits survive a restart, only the uses
+// that are recorded count, which lets callers count successful commands only.
+type RateLimiter struct {
+	commandUseRepo *repository.CommandUse
+}
+
+func NewRateLimiter(commandUseRepo *repository.CommandUse) *RateLimiter {
+	return &RateLimiter{
+		commandUseRepo: commandUseRepo,
+	}
+}
+
+// RetryAt returns when the member can use the command again, the zero time if the member can use it now.
+func (r *RateLimiter) RetryAt(
+	guildID, userID, command string,
+	limit model.RateLimit,
+	now time.Time,
+) (time.Time, error) {
+	if limit.Unlimited() {
+		return time.Time{}, nil
+	}
+	uses, err := r.commandUseRepo.GetUsesSince(guildID, userID, command, now.Add(-limit.Window))
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(uses) < limit.Uses {
+		return time.Time{}, nil
+	}
+	// A use is available again once enough uses have left the window
+	return uses[len(uses)-limit.Uses].UsedAt.Add(limit.Window), nil
+}
+
+// Record counts a use of the command, uses that have left the window are forgotten.
+func (r *RateLimiter) Record(guildID, userID, command string, limit model.RateLimit, now time.Time) error {
+	if limit.Unlimited() {
+		return nil
+	}
+	err := r.commandUseRepo.DeleteBefore(guildID, userID, command, now.Add(-limit.Window))
+	if err != nil {
+		return err
+	}
+	return r.commandUseRepo.Insert(&repomodel.CommandUse{
+		GuildID: guildID,
+		UserID:  userID,
+		Command: command,
+		UsedAt:  now,
+	})
+}
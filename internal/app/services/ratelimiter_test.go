@@ -0,0 +1,88 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+	"tmff-discord-app/internal/app/repository"
+	"tmff-discord-app/internal/app/services"
+	"tmff-discord-app/internal/app/services/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Parallel()
+	limit := model.RateLimit{Uses: 3, Window: time.Hour}
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Test quota per window", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		rateLimiter := services.NewRateLimiter(repository.NewCommandUse(dbx, &queryTimeout))
+
+		for minutes := range 3 {
+			now := start.Add(time.Duration(minutes*10) * time.Minute)
+			retryAt, err := rateLimiter.RetryAt(testGuildID, "user-1", "register-game", limit, now)
+			require.NoError(t, err)
+			assert.True(t, retryAt.IsZero())
+			err = rateLimiter.Record(testGuildID, "user-1", "register-game", limit, now)
+			require.NoError(t, err)
+		}
+
+		// The first use leaves the window an hour after it was recorded
+		retryAt, err := rateLimiter.RetryAt(testGuildID, "user-1", "register-game", limit, start.Add(30*time.Minute))
+		require.NoError(t, err)
+		assert.True(t, start.Add(time.Hour).Equal(retryAt), retryAt)
+
+		retryAt, err = rateLimiter.RetryAt(testGuildID, "user-1", "register-game", limit, start.Add(time.Hour))
+		require.NoError(t, err)
+		assert.True(t, retryAt.IsZero())
+
+		// Other commands, members and guilds have their own quota
+		retryAt, err = rateLimiter.RetryAt(testGuildID, "user-1", "register-me", limit, start.Add(30*time.Minute))
+		require.NoError(t, err)
+		assert.True(t, retryAt.IsZero())
+		retryAt, err = rateLimiter.RetryAt(testGuildID, "user-2", "register-game", limit, start.Add(30*time.Minute))
+		require.NoError(t, err)
+		assert.True(t, retryAt.IsZero())
+		retryAt, err = rateLimiter.RetryAt("guild-2", "user-1", "register-game", limit, start.Add(30*time.Minute))
+		require.NoError(t, err)
+		assert.True(t, retryAt.IsZero())
+	})
+
+	t.Run("Test uses are stored", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		single := model.RateLimit{Uses: 1, Window: time.Hour}
+
+		err := services.NewRateLimiter(repository.NewCommandUse(dbx, &queryTimeout)).
+			Record(testGuildID, "user-1", "register-me", single, start)
+		require.NoError(t, err)
+
+		// A new rate limiter after a restart still knows the use
+		rateLimiter := services.NewRateLimiter(repository.NewCommandUse(dbx, &queryTimeout))
+		retryAt, err := rateLimiter.RetryAt(testGuildID, "user-1", "register-me", single, start.Add(time.Minute))
+		require.NoError(t, err)
+		assert.True(t, start.Add(time.Hour).Equal(retryAt), retryAt)
+	})
+
+	t.Run("Test unlimited", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		rateLimiter := services.NewRateLimiter(repository.NewCommandUse(dbx, &queryTimeout))
+		// A guild that set its rate limit window to zero
+		unlimited := model.RateLimit{Uses: 1}
+
+		for range 3 {
+			retryAt, err := rateLimiter.RetryAt(testGuildID, "user-1", "register-game", unlimited, start)
+			require.NoError(t, err)
+			assert.True(t, retryAt.IsZero())
+			err = rateLimiter.Record(testGuildID, "user-1", "register-game", unlimited, start)
+			require.NoError(t, err)
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"tmff-discord-app/internal/app/repository"
+	repomodel "tmff-discord-app/internal/app/repository/model"
+	"tmff-discord-app/internal/app/services/model"
+
+	"github.com/pkg/errors"
+)
+
+// maxListedJobs is the number of jobs of each status that are listed.
+const maxListedJobs = 10
+
+// RegistrationQueue keeps the games waiting to be registered, the queue survives a restart of the bot.
+type RegistrationQueue struct {
+	registrationJobRepo *repository.RegistrationJob
+}
+
+func NewRegistrationQueue(registrationJobRepo *repository.RegistrationJob) *RegistrationQueue {
+	return &RegistrationQueue{
+		registrationJobRepo: registrationJobRepo,
+	}
+}
+
+// Enqueue queues the job, links that can't be a game are rejected right away.
+func (q *RegistrationQueue) Enqueue(job *model.RegistrationJob) error {
+	if _, err := getTableID(job.GameLink); err != nil {
+		return errors.Wrap(err, "invalid game link")
+	}
+	job.Status = model.JobQueued
+	dbJob := toDBRegistrationJob(job)
+	err := q.registrationJobRepo.Insert(dbJob)
+	if err != nil {
+		return err
+	}
+	job.ID = dbJob.ID
+	return nil
+}
+
+// Position returns the number of queued jobs ahead of the job.
+func (q *RegistrationQueue) Position(job *model.RegistrationJob) (int, error) {
+	return q.registrationJobRepo.CountBefore(job.ID, string(model.JobQueued))
+}
+
+// Next claims the oldest queued job for scraping, it returns nil when the queue is empty.
+func (q *RegistrationQueue) Next() (*model.RegistrationJob, error) {
+	job, err := q.registrationJobRepo.ClaimOldest(string(model.JobQueued), string(model.JobScraping))
+	if errors.Is(err, repository.ErrRegistrationJobNotFound) {
+		return nil, nil //nolint:nilnil // An empty queue is not an error.
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toRegistrationJob(job), nil
+}
+
+func (q *RegistrationQueue) Rated(job *model.RegistrationJob) error {
+	job.Status = model.JobRated
+	return q.registrationJobRepo.Finish(job.ID, string(job.Status), "")
+}
+
+// Failed records why the job could not register its game.
+func (q *RegistrationQueue) Failed(job *model.RegistrationJob, cause error) error {
+	job.Status = model.JobFailed
+	job.Error = cause.Error()
+	return q.registrationJobRepo.Finish(job.ID, string(job.Status), job.Error)
+}
+
+// Retry queues a failed job of the guild again, the worker updates the response of the given interaction instead.
+func (q *RegistrationQueue) Retry(
+	guildID string,
+	jobID int,
+	appID, interactionToken string,
+) (*model.RegistrationJob, error) {
+	job, err := q.registrationJobRepo.Requeue(&repomodel.RegistrationJob{
+		ID:               jobID,
+		GuildID:          guildID,
+		AppID:            appID,
+		InteractionToken: interactionToken,
+		Status:           string(model.JobQueued),
+	}, string(model.JobFailed))
+	if errors.Is(err, repository.ErrRegistrationJobNotFound) {
+		return nil, errors.New("there is no failed registration job with that ID")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toRegistrationJob(job), nil
+}
+
+// GetPendingJobs returns the jobs of the guild that are being scraped or wait in the queue, in queue order.
+func (q *RegistrationQueue) GetPendingJobs(guildID string) ([]*model.RegistrationJob, error) {
+	var jobs []*model.RegistrationJob
+	for _, status := range []model.JobStatus{model.JobScraping, model.JobQueued} {
+		dbJobs, err := q.registrationJobRepo.GetJobs(guildID, string(status), maxListedJobs)
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range dbJobs {
+			jobs = append(jobs, toRegistrationJob(job))
+		}
+	}
+	return jobs, nil
+}
+
+// GetFailedJobs returns the latest failed jobs of the guild, oldest first.
+func (q *RegistrationQueue) GetFailedJobs(guildID string) ([]*model.RegistrationJob, error) {
+	dbJobs, err := q.registrationJobRepo.GetJobs(guildID, string(model.JobFailed), maxListedJobs)
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]*model.RegistrationJob, len(dbJobs))
+	for i, job := range dbJobs {
+		jobs[i] = toRegistrationJob(job)
+	}
+	return jobs, nil
+}
+
+// ResumeInterrupted queues the jobs again that were being scraped when the bot stopped.
+func (q *RegistrationQueue) ResumeInterrupted() error {
+	return q.registrationJobRepo.ResetStatus(string(model.JobScraping), string(model.JobQueued))
+}
+
+func toDBRegistrationJob(job *model.RegistrationJob) *repomodel.RegistrationJob {
+	return &repomodel.RegistrationJob{
+		ID:               job.ID,
+		GuildID:          job.GuildID,
+		GameLink:         job.GameLink,
+		UserID:           job.UserID,
+		ChannelID:        job.ChannelID,
+		AppID:            job.AppID,
+		InteractionToken: job.InteractionToken,
+		Status:           string(job.Status),
+		Attempts:         job.Attempts,
+		Error:            job.Error,
+	}
+}
+
+func toRegistrationJob(job *repomodel.RegistrationJob) *model.RegistrationJob {
+	return &model.RegistrationJob{
+		ID:               job.ID,
+		GuildID:          job.GuildID,
+		GameLink:         job.GameLink,
+		UserID:           job.UserID,
+		ChannelID:        job.ChannelID,
+		AppID:            job.AppID,
+		InteractionToken: job.InteractionToken,
+		Status:           model.JobStatus(job.Status),
+		Attempts:         job.Attempts,
+		Error:            job.Error,
+		CreatedAt:        job.CreatedAt,
+		UpdatedAt:        job.UpdatedAt,
+	}
+}
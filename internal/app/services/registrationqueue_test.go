@@ -0,0 +1,121 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+	"tmff-discord-app/internal/app/repository"
+	"tmff-discord-app/internal/app/services"
+	"tmff-discord-app/internal/app/services/model"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistrationQueue(t *testing.T) {
+	t.Parallel()
+	newJob := func(gameLink string) *model.RegistrationJob {
+		return &model.RegistrationJob{
+			GuildID:          testGuildID,
+			GameLink:         gameLink,
+			UserID:           "user-1",
+			ChannelID:        "channel-1",
+			AppID:            "app-1",
+			InteractionToken: "token-1",
+		}
+	}
+
+	t.Run("Test jobs go through the queue", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		queue := services.NewRegistrationQueue(repository.NewRegistrationJob(dbx, &queryTimeout))
+
+		err := queue.Enqueue(newJob("https://boardgamearena.com/player?id=1"))
+		require.Error(t, err)
+
+		first := newJob("https://boardgamearena.com/table?table=1")
+		require.NoError(t, queue.Enqueue(first))
+		second := newJob("https://boardgamearena.com/table?table=2")
+		require.NoError(t, queue.Enqueue(second))
+		assert.Equal(t, model.JobQueued, second.Status)
+		position, err := queue.Position(second)
+		require.NoError(t, err)
+		assert.Equal(t, 1, position)
+
+		job, err := queue.Next()
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, job.ID)
+		assert.Equal(t, model.JobScraping, job.Status)
+
+		pending, err := queue.GetPendingJobs(testGuildID)
+		require.NoError(t, err)
+		require.Len(t, pending, 2)
+		assert.Equal(t, first.ID, pending[0].ID)
+		assert.Equal(t, second.ID, pending[1].ID)
+
+		require.NoError(t, queue.Rated(job))
+		job, err = queue.Next()
+		require.NoError(t, err)
+		assert.Equal(t, second.ID, job.ID)
+		require.NoError(t, queue.Failed(job, errors.New("table does not exist")))
+
+		job, err = queue.Next()
+		require.NoError(t, err)
+		assert.Nil(t, job)
+
+		pending, err = queue.GetPendingJobs(testGuildID)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+		failed, err := queue.GetFailedJobs(testGuildID)
+		require.NoError(t, err)
+		require.Len(t, failed, 1)
+		assert.Equal(t, second.ID, failed[0].ID)
+		assert.Equal(t, "table does not exist", failed[0].Error)
+	})
+
+	t.Run("Test retry failed job", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		queue := services.NewRegistrationQueue(repository.NewRegistrationJob(dbx, &queryTimeout))
+
+		job := newJob("https://boardgamearena.com/table?table=1")
+		require.NoError(t, queue.Enqueue(job))
+		_, err := queue.Retry(testGuildID, job.ID, "app-1", "token-2")
+		require.Error(t, err, "only failed jobs can be retried")
+
+		claimed, err := queue.Next()
+		require.NoError(t, err)
+		require.NoError(t, queue.Failed(claimed, errors.New("timeout")))
+
+		_, err = queue.Retry("guild-2", job.ID, "app-1", "token-2")
+		require.Error(t, err)
+		retried, err := queue.Retry(testGuildID, job.ID, "app-1", "token-2")
+		require.NoError(t, err)
+		assert.Equal(t, model.JobQueued, retried.Status)
+		assert.Equal(t, "token-2", retried.InteractionToken)
+
+		claimed, err = queue.Next()
+		require.NoError(t, err)
+		assert.Equal(t, job.ID, claimed.ID)
+		assert.Equal(t, 2, claimed.Attempts)
+	})
+
+	t.Run("Test interrupted jobs are resumed", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		queue := services.NewRegistrationQueue(repository.NewRegistrationJob(dbx, &queryTimeout))
+
+		job := newJob("https://boardgamearena.com/table?table=1")
+		require.NoError(t, queue.Enqueue(job))
+		_, err := queue.Next()
+		require.NoError(t, err)
+
+		require.NoError(t, queue.ResumeInterrupted())
+		resumed, err := queue.Next()
+		require.NoError(t, err)
+		assert.Equal(t, job.ID, resumed.ID)
+	})
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"strings"
+	"tmff-discord-app/internal/app/repository"
+	repomodel "tmff-discord-app/internal/app/repository/model"
+	"tmff-discord-app/internal/app/services/model"
+
+	"github.com/pkg/errors"
+)
+
+const fanFactionSettingSeparator = ","
+
+type Season struct {
+	seasonRepo *repository.Season
+	playerRepo *repository.Player
+}
+
+func NewSeason(seasonRepo *repository.Season, playerRepo *repository.Player) *Season {
+	return &Season{
+		seasonRepo: seasonRepo,
+		playerRepo: playerRepo,
+	}
+}
+
+// GetRules returns the rules of the current season.
+func (s *Season) GetRules() (*model.SeasonRules, error) {
+	return getSeasonRules(s.seasonRepo)
+}
+
+// GetNextSeasonRules returns the rules a new season starts from, the rules of the current season or the default rules
+// if the league has no season yet.
+func (s *Season) GetNextSeasonRules() (*model.SeasonRules, error) {
+	rules, err := getSeasonRules(s.seasonRepo)
+	if errors.Is(err, repository.ErrSeasonNotFound) {
+		return model.DefaultSeasonRules(), nil
+	}
+	return rules, err
+}
+
+func (s *Season) SetMinGames(minGames int) error {
+	if minGames < 0 {
+		return errors.New("minimum games can't be negative")
+	}
+	return s.seasonRepo.SetMinGames(minGames)
+}
+
+func (s *Season) SetSignUpRequired(signUpRequired bool) error {
+	return s.seasonRepo.SetSignUpRequired(signUpRequired)
+}
+
+// JoinSeason signs a registered player up for the current season before they have played any games.
+func (s *Season) JoinSeason(playerName string) (*repomodel.SeasonParticipant, error) {
+	season, err := s.seasonRepo.GetCurrentSeason()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get current season")
+	}
+	if season.EndedAt != nil {
+		return nil, errors.New("the season has ended, no more players can join")
+	}
+
+	player, err := s.playerRepo.GetPlayer(playerName)
+	if err != nil {
+		return nil, err
+	}
+	return s.seasonRepo.JoinSeason(player.ID)
+}
+
+func (s *Season) CreateSeason(rules *model.SeasonRules) error {
+	if rules.SeasonName == "" {
+		return errors.New("season name is required")
+	}
+	if rules.KFactor <= 0 {
+		return errors.New("k factor has to be positive")
+	}
+	if rules.EloFloor > rules.StartElo {
+		return errors.New("elo floor can't be above the start elo")
+	}
+	//nolint:mnd // A game needs at least two players to be rated
+	if rules.MinPlayers < 2 {
+		return errors.New("minimum players has to be at least 2")
+	}
+	if rules.MaxGameAgeDays <= 0 {
+		return errors.New("max game age has to be positive")
+	}
+	if len(rules.AllowedFanFactionSettings) == 0 {
+		return errors.New("at least one fan faction setting has to be allowed")
+	}
+
+	return s.seasonRepo.CreateSeason(seasonRulesToRepo(rules))
+}
+
+// SeedRules stores rules for the seasons created before the rules were stored per season. They get the default rules
+// with the K factor and max game age the bot was configured with back then, without those the seasons can't be rated.
+func (s *Season) SeedRules(kFactor, maxGameAgeDays int) error {
+	names, err := s.seasonRepo.GetSeasonsWithoutRules()
+	if err != nil {
+		return errors.Wrap(err, "failed to get seasons without rules")
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	if kFactor <= 0 || maxGameAgeDays <= 0 {
+		return errors.Errorf(
+			"seasons without rules: %s, set eloKFactor and maxGameAgeDays in config.yaml to the values they were rated by",
+			strings.Join(names, ", "),
+		)
+	}
+
+	rules := model.DefaultSeasonRules()
+	rules.KFactor = kFactor
+	rules.MaxGameAgeDays = maxGameAgeDays
+	return s.seasonRepo.InsertMissingRules(seasonRulesToRepo(rules))
+}
+
+func getSeasonRules(seasonRepo *repository.Season) (*model.SeasonRules, error) {
+	rules, err := seasonRepo.GetRules()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get season rules")
+	}
+	return seasonRulesFromRepo(rules), nil
+}
+
+func seasonRulesToRepo(rules *model.SeasonRules) *repomodel.SeasonRules {
+	settings := make([]string, len(rules.AllowedFanFactionSettings))
+	for i, setting := range rules.AllowedFanFactionSettings {
+		settings[i] = setting.String()
+	}
+	return &repomodel.SeasonRules{
+		SeasonName:                rules.SeasonName,
+		KFactor:                   rules.KFactor,
+		StartElo:                  rules.StartElo,
+		EloFloor:                  rules.EloFloor,
+		MinPlayers:                rules.MinPlayers,
+		MaxGameAgeDays:            rules.MaxGameAgeDays,
+		AllowedFanFactionSettings: strings.Join(settings, fanFactionSettingSeparator),
+	}
+}
+
+func seasonRulesFromRepo(rules *repomodel.SeasonRules) *model.SeasonRules {
+	var settings []model.FanFactionSetting
+	for _, setting := range strings.Split(rules.AllowedFanFactionSettings, fanFactionSettingSeparator) {
+		settings = append(settings, model.FanFactionSettingFromString(setting))
+	}
+	return &model.SeasonRules{
+		SeasonName:                rules.SeasonName,
+		KFactor:                   rules.KFactor,
+		StartElo:                  rules.StartElo,
+		EloFloor:                  rules.EloFloor,
+		MinPlayers:                rules.MinPlayers,
+		MaxGameAgeDays:            rules.MaxGameAgeDays,
+		AllowedFanFactionSettings: settings,
+	}
+}
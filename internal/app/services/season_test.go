@@ -0,0 +1,220 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+	"tmff-discord-app/internal/app/config"
+	"tmff-discord-app/internal/app/db"
+	"tmff-discord-app/internal/app/repository"
+	"tmff-discord-app/internal/app/services"
+	"tmff-discord-app/internal/app/services/model"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeason(t *testing.T) {
+	t.Parallel()
+	t.Run("Test rules of a new season are used for rating", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		firstSeasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		secondSeasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "Second Fan Faction Season")
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "Second Fan Faction Season")
+		gameService := services.NewGame(playerRepo, gameRepo, secondSeasonRepo)
+
+		err := services.NewSeason(firstSeasonRepo, playerRepo).CreateSeason(&model.SeasonRules{
+			SeasonName:                "Second Fan Faction Season",
+			KFactor:                   32,
+			StartElo:                  1500,
+			EloFloor:                  0,
+			MinPlayers:                3,
+			MaxGameAgeDays:            30,
+			AllowedFanFactionSettings: []model.FanFactionSetting{model.OnNoFireAndIce},
+		})
+		require.NoError(t, err)
+
+		rules, err := services.NewSeason(secondSeasonRepo, playerRepo).GetRules()
+		require.NoError(t, err)
+		assert.Equal(t, 32, rules.KFactor)
+		assert.True(t, rules.AllowsFanFactionSetting(model.OnNoFireAndIce))
+		assert.False(t, rules.AllowsFanFactionSetting(model.On))
+
+		err = playerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 2", "2")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 3", "3")
+		require.NoError(t, err)
+
+		currentTime := time.Now()
+		gameOutcome := &model.GameOutcome{
+			ID: "1",
+			Players: []*model.PlayerResult{
+				{Name: "Player 1", Score: 100},
+				{Name: "Player 2", Score: 200},
+				{Name: "Unregistered player", Score: 300},
+			},
+			FanFactionSetting: model.OnNoFireAndIce,
+			CreationTime:      &currentTime,
+		}
+		_, err = gameService.RegisterGame(gameOutcome)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "less than 3 registered players found for game")
+
+		gameOutcome.Players[2].Name = "Player 3"
+		players, err := gameService.RegisterGame(gameOutcome)
+		require.NoError(t, err)
+		assert.Len(t, players, 3)
+		assert.Equal(t, "Player 3", players[0].Name)
+		assert.Equal(t, 1500, players[0].EloBefore)
+		assert.Equal(t, 10, players[0].EloChange)
+		assert.Equal(t, "Player 1", players[2].Name)
+		assert.Equal(t, -10, players[2].EloChange)
+	})
+
+	t.Run("Test validate game outcome against rules", func(t *testing.T) {
+		t.Parallel()
+		rules := &model.SeasonRules{
+			MaxGameAgeDays:            30,
+			AllowedFanFactionSettings: []model.FanFactionSetting{model.OnNoFireAndIce},
+		}
+		oldTime := time.Now().Add(-31 * 24 * time.Hour)
+		gameOutcome := &model.GameOutcome{
+			ID: "1",
+			Players: []*model.PlayerResult{
+				{Name: "Player 1", Score: 100},
+				{Name: "Player 2", Score: 200},
+			},
+			FanFactionSetting: model.On,
+			CreationTime:      &oldTime,
+		}
+		err := gameOutcome.Validate(rules)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fan faction setting On - with Fire & Ice is not allowed this season")
+
+		gameOutcome.FanFactionSetting = model.Off
+		err = gameOutcome.Validate(rules)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fan factions are not enabled")
+
+		gameOutcome.FanFactionSetting = model.OnNoFireAndIce
+		err = gameOutcome.Validate(rules)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "game is too old (more than 30 days)")
+	})
+
+	t.Run("Test create season with invalid rules", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		seasonService := services.NewSeason(
+			repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season"),
+			repository.NewPlayer(dbx, &queryTimeout, testGuildID),
+		)
+
+		rules, err := seasonService.GetRules()
+		require.NoError(t, err)
+		rules.SeasonName = "Second Fan Faction Season"
+		rules.MinPlayers = 1
+		err = seasonService.CreateSeason(rules)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "minimum players has to be at least 2")
+	})
+
+	t.Run("Test sign up restricts rated games", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
+		seasonService := services.NewSeason(seasonRepo, playerRepo)
+		leaderboardService := services.NewLeaderboard(seasonRepo, playerRepo, gameRepo)
+
+		err := playerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 2", "2")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 3", "3")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 4", "4")
+		require.NoError(t, err)
+
+		_, err = seasonService.JoinSeason("Player 1")
+		require.NoError(t, err)
+		_, err = seasonService.JoinSeason("Player 2")
+		require.NoError(t, err)
+		_, err = seasonService.JoinSeason("Player 3")
+		require.NoError(t, err)
+		_, err = seasonService.JoinSeason("Unregistered player")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, repository.ErrPlayerNotFound))
+		err = seasonService.SetSignUpRequired(true)
+		require.NoError(t, err)
+
+		currentTime := time.Now()
+		players, err := gameService.RegisterGame(&model.GameOutcome{
+			ID: "1",
+			Players: []*model.PlayerResult{
+				{Name: "Player 1", Score: 100},
+				{Name: "Player 2", Score: 200},
+				{Name: "Player 4", Score: 300},
+			},
+			FanFactionSetting: model.OnNoFireAndIce,
+			CreationTime:      &currentTime,
+		})
+		require.NoError(t, err)
+		assert.Len(t, players, 2)
+		assert.Equal(t, "Player 2", players[0].Name)
+		assert.Equal(t, "Player 1", players[1].Name)
+
+		leaderboard, err := leaderboardService.GetLeaderboard()
+		require.NoError(t, err)
+		assert.Len(t, leaderboard.Entries, 3)
+		assert.Equal(t, "Player 2", leaderboard.Entries[0].PlayerName)
+		assert.Equal(t, 1, leaderboard.Entries[0].GamesPlayed)
+		assert.Equal(t, "Player 3", leaderboard.Entries[1].PlayerName)
+		assert.Equal(t, 0, leaderboard.Entries[1].GamesPlayed)
+		assert.Equal(t, "Player 1", leaderboard.Entries[2].PlayerName)
+	})
+	t.Run("Test rules are seeded for seasons created before rules were stored", func(t *testing.T) {
+		t.Parallel()
+		dbx, err := db.SetupDatabase(&config.Config{DBFile: ":memory:"})
+		require.NoError(t, err)
+		queryTimeout := 2 * time.Second
+		err = repository.NewGuildSettings(dbx, &queryTimeout).AssignUnscopedData(testGuildID, "First Fan Faction Season")
+		require.NoError(t, err)
+		seasonService := services.NewSeason(
+			repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season"),
+			repository.NewPlayer(dbx, &queryTimeout, testGuildID),
+		)
+
+		_, err = seasonService.GetRules()
+		require.ErrorIs(t, err, repository.ErrSeasonNotFound)
+		err = seasonService.SeedRules(0, 0)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "seasons without rules: First Fan Faction Season")
+
+		err = seasonService.SeedRules(32, 30)
+		require.NoError(t, err)
+		rules, err := seasonService.GetRules()
+		require.NoError(t, err)
+		assert.Equal(t, 32, rules.KFactor)
+		assert.Equal(t, 30, rules.MaxGameAgeDays)
+		assert.Equal(t, 1000, rules.StartElo)
+
+		// Seasons that have rules keep them
+		err = seasonService.SeedRules(0, 0)
+		require.NoError(t, err)
+		err = seasonService.SeedRules(64, 60)
+		require.NoError(t, err)
+		rules, err = seasonService.GetRules()
+		require.NoError(t, err)
+		assert.Equal(t, 32, rules.KFactor)
+	})
+}
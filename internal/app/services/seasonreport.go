@@ -0,0 +1,214 @@
+package services
+
+import (
+	"tmff-discord-app/internal/app/repository"
+	repomodel "tmff-discord-app/internal/app/repository/model"
+	"tmff-discord-app/internal/app/services/model"
+
+	"github.com/pkg/errors"
+)
+
+type SeasonReport struct {
+	seasonRepo         *repository.Season
+	gameRepo           *repository.Game
+	playerRepo         *repository.Player
+	leaderboardService *Leaderboard
+}
+
+func NewSeasonReport(
+	seasonRepo *repository.Season,
+	gameRepo *repository.Game,
+	playerRepo *repository.Player,
+	leaderboardService *Leaderboard,
+) *SeasonReport {
+	return &SeasonReport{
+		seasonRepo:         seasonRepo,
+		gameRepo:           gameRepo,
+		playerRepo:         playerRepo,
+		leaderboardService: leaderboardService,
+	}
+}
+
+// CloseSeason ends the current season, archives the final standings of the qualified players and returns the
+// season report.
+func (s *SeasonReport) CloseSeason() (*model.SeasonReport, error) {
+	season, err := s.seasonRepo.GetCurrentSeason()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get current season")
+	}
+	if season.EndedAt != nil {
+		return nil, repository.ErrSeasonAlreadyEnded
+	}
+
+	report, err := s.GetSeasonReport()
+	if err != nil {
+		return nil, err
+	}
+
+	finalRanks := make(map[int]int)
+	for i, entry := range report.Standings.Entries {
+		finalRanks[entry.PlayerID] = i + 1
+	}
+	err = s.seasonRepo.EndSeason(finalRanks)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to end season")
+	}
+	return report, nil
+}
+
+func (s *SeasonReport) GetSeasonReport() (*model.SeasonReport, error) {
+	season, err := s.seasonRepo.GetCurrentSeason()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get current season")
+	}
+
+	rules, err := getSeasonRules(s.seasonRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	standings, err := s.leaderboardService.GetLeaderboard()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get standings")
+	}
+
+	participants, err := s.gameRepo.GetSeasonGameParticipants()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get season game participants")
+	}
+
+	players, err := s.playerRepo.GetPlayers()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get players")
+	}
+	playerNames := make(PlayerIDToName)
+	for _, player := range players {
+		playerNames[player.ID] = player.Name
+	}
+
+	return &model.SeasonReport{
+		SeasonName:       season.Name,
+		Standings:        standings,
+		MostGamesPlayed:  mostGamesPlayed(standings),
+		BiggestEloGain:   biggestEloGain(participants, playerNames),
+		HighestScore:     highestScore(participants, playerNames),
+		LongestWinStreak: longestWinStreak(participants, playerNames),
+		MostImproved:     mostImproved(participants, standings, rules.EloFloor),
+	}, nil
+}
+
+func mostGamesPlayed(standings *model.Leaderboard) *model.SeasonHighlight {
+	var highlight *model.SeasonHighlight
+	for _, entry := range standings.AllEntries() {
+		if highlight == nil || entry.GamesPlayed > highlight.Value {
+			highlight = &model.SeasonHighlight{
+				PlayerName: entry.PlayerName,
+				Value:      entry.GamesPlayed,
+			}
+		}
+	}
+	return highlight
+}
+
+func biggestEloGain(participants []*repomodel.GameParticipant, playerNames PlayerIDToName) *model.SeasonHighlight {
+	var highlight *model.SeasonHighlight
+	for _, participant := range participants {
+		if participant.EloChange <= 0 {
+			continue
+		}
+		if highlight == nil || participant.EloChange > highlight.Value {
+			highlight = &model.SeasonHighlight{
+				PlayerName: playerNames[participant.PlayerID],
+				Value:      participant.EloChange,
+				GameID:     participant.GameID,
+			}
+		}
+	}
+	return highlight
+}
+
+func highestScore(participants []*repomodel.GameParticipant, playerNames PlayerIDToName) *model.SeasonHighlight {
+	var highlight *model.SeasonHighlight
+	for _, participant := range participants {
+		if highlight == nil || participant.Score > highlight.Value {
+			highlight = &model.SeasonHighlight{
+				PlayerName: playerNames[participant.PlayerID],
+				Value:      participant.Score,
+				GameID:     participant.GameID,
+			}
+		}
+	}
+	return highlight
+}
+
+// longestWinStreak finds the most consecutive games won, a win is finishing first among the registered players. As in
+// the all-time leaderboard, players that share first place all win the game.
+func longestWinStreak(participants []*repomodel.GameParticipant, playerNames PlayerIDToName) *model.SeasonHighlight {
+	currentStreaks := make(map[int]int)
+	var highlight *model.SeasonHighlight
+	for _, game := range groupByGame(participants) {
+		for _, participant := range game {
+			if finishingPosition(participant, game) > 1 {
+				currentStreaks[participant.PlayerID] = 0
+				continue
+			}
+			currentStreaks[participant.PlayerID]++
+			if highlight == nil || currentStreaks[participant.PlayerID] > highlight.Value {
+				highlight = &model.SeasonHighlight{
+					PlayerName: playerNames[participant.PlayerID],
+					Value:      currentStreaks[participant.PlayerID],
+				}
+			}
+		}
+	}
+	return highlight
+}
+
+// mostImproved finds the player with the largest climb from their lowest Elo of the season to their final Elo. The
+// lowest Elo is the lowest a player had before or after any of their games, the Elo after a game doesn't drop below
+// the Elo floor.
+func mostImproved(
+	participants []*repomodel.GameParticipant,
+	standings *model.Leaderboard,
+	eloFloor int,
+) *model.SeasonHighlight {
+	lowestElo := make(PlayerIDToCurrentElo)
+	for _, participant := range participants {
+		low := min(participant.EloBefore, max(participant.EloBefore+participant.EloChange, eloFloor))
+		lowest, ok := lowestElo[participant.PlayerID]
+		if !ok || low < lowest {
+			lowestElo[participant.PlayerID] = low
+		}
+	}
+
+	var highlight *model.SeasonHighlight
+	for _, entry := range standings.AllEntries() {
+		lowest, ok := lowestElo[entry.PlayerID]
+		if !ok {
+			continue
+		}
+		improvement := entry.Elo - lowest
+		if improvement <= 0 {
+			continue
+		}
+		if highlight == nil || improvement > highlight.Value {
+			highlight = &model.SeasonHighlight{
+				PlayerName: entry.PlayerName,
+				Value:      improvement,
+			}
+		}
+	}
+	return highlight
+}
+
+// groupByGame splits participants ordered by registration into one slice per game.
+func groupByGame(participants []*repomodel.GameParticipant) [][]*repomodel.GameParticipant {
+	var games [][]*repomodel.GameParticipant
+	for i, participant := range participants {
+		if i == 0 || participants[i-1].GameID != participant.GameID {
+			games = append(games, []*repomodel.GameParticipant{})
+		}
+		games[len(games)-1] = append(games[len(games)-1], participant)
+	}
+	return games
+}
@@ -0,0 +1,264 @@
+package services_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+	"tmff-discord-app/internal/app/repository"
+	"tmff-discord-app/internal/app/services"
+	"tmff-discord-app/internal/app/services/model"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeasonReport(t *testing.T) {
+	t.Parallel()
+	t.Run("Test close season", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
+		leaderboardService := services.NewLeaderboard(seasonRepo, playerRepo, gameRepo)
+		seasonReportService := services.NewSeasonReport(seasonRepo, gameRepo, playerRepo, leaderboardService)
+
+		err := playerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 2", "2")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 3", "3")
+		require.NoError(t, err)
+
+		currentTime := time.Now()
+		gameScores := [][]int{
+			{100, 120, 90},
+			{80, 130, 110},
+			{150, 100, 120},
+			{140, 90, 100},
+			{160, 110, 100},
+		}
+		for i, scores := range gameScores {
+			_, err = gameService.RegisterGame(&model.GameOutcome{
+				ID: strconv.Itoa(i + 1),
+				Players: []*model.PlayerResult{
+					{Name: "Player 1", Score: scores[0]},
+					{Name: "Player 2", Score: scores[1]},
+					{Name: "Player 3", Score: scores[2]},
+				},
+				FanFactionSetting: model.On,
+				CreationTime:      &currentTime,
+			})
+			require.NoError(t, err)
+		}
+
+		report, err := seasonReportService.CloseSeason()
+		require.NoError(t, err)
+
+		assert.Equal(t, "First Fan Faction Season", report.SeasonName)
+		assert.Len(t, report.Standings.Entries, 3)
+		assert.Equal(t, "Player 1", report.Standings.Entries[0].PlayerName)
+		assert.Equal(t, "Player 1", report.MostGamesPlayed.PlayerName)
+		assert.Equal(t, 5, report.MostGamesPlayed.Value)
+		assert.Equal(t, "Player 1", report.BiggestEloGain.PlayerName)
+		assert.Equal(t, 24, report.BiggestEloGain.Value)
+		assert.Equal(t, "Player 1", report.HighestScore.PlayerName)
+		assert.Equal(t, 160, report.HighestScore.Value)
+		assert.Equal(t, "5", report.HighestScore.GameID)
+		assert.Equal(t, "Player 1", report.LongestWinStreak.PlayerName)
+		assert.Equal(t, 3, report.LongestWinStreak.Value)
+		assert.Equal(t, "Player 1", report.MostImproved.PlayerName)
+		assert.Equal(t, 64, report.MostImproved.Value)
+
+		_, err = gameService.RegisterGame(&model.GameOutcome{
+			ID: "6",
+			Players: []*model.PlayerResult{
+				{Name: "Player 1", Score: 100},
+				{Name: "Player 2", Score: 90},
+			},
+			FanFactionSetting: model.On,
+			CreationTime:      &currentTime,
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "the season has ended")
+
+		_, err = seasonReportService.CloseSeason()
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, repository.ErrSeasonAlreadyEnded))
+	})
+
+	t.Run("Test close season only ranks qualified players", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
+		leaderboardService := services.NewLeaderboard(seasonRepo, playerRepo, gameRepo)
+		seasonReportService := services.NewSeasonReport(seasonRepo, gameRepo, playerRepo, leaderboardService)
+
+		err := playerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 2", "2")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 3", "3")
+		require.NoError(t, err)
+		err = seasonRepo.SetMinGames(2)
+		require.NoError(t, err)
+
+		currentTime := time.Now()
+		_, err = gameService.RegisterGame(&model.GameOutcome{
+			ID: "1",
+			Players: []*model.PlayerResult{
+				{Name: "Player 1", Score: 100},
+				{Name: "Player 2", Score: 90},
+				{Name: "Player 3", Score: 150},
+			},
+			FanFactionSetting: model.On,
+			CreationTime:      &currentTime,
+		})
+		require.NoError(t, err)
+		_, err = gameService.RegisterGame(&model.GameOutcome{
+			ID: "2",
+			Players: []*model.PlayerResult{
+				{Name: "Player 1", Score: 100},
+				{Name: "Player 2", Score: 90},
+			},
+			FanFactionSetting: model.On,
+			CreationTime:      &currentTime,
+		})
+		require.NoError(t, err)
+
+		report, err := seasonReportService.CloseSeason()
+		require.NoError(t, err)
+		assert.Len(t, report.Standings.Entries, 2)
+		assert.Len(t, report.Standings.Unqualified, 1)
+
+		participants, err := seasonRepo.GetAll()
+		require.NoError(t, err)
+		finalRanks := make(map[int]*int)
+		for _, participant := range participants {
+			finalRanks[participant.PlayerID] = participant.FinalRank
+		}
+		assert.Equal(t, 1, *finalRanks[1])
+		assert.Equal(t, 2, *finalRanks[2])
+		assert.Nil(t, finalRanks[3])
+	})
+
+	t.Run("Test shared first place continues the win streak", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
+		leaderboardService := services.NewLeaderboard(seasonRepo, playerRepo, gameRepo)
+		seasonReportService := services.NewSeasonReport(seasonRepo, gameRepo, playerRepo, leaderboardService)
+
+		err := playerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 2", "2")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 3", "3")
+		require.NoError(t, err)
+
+		currentTime := time.Now()
+		gameScores := [][]int{
+			{100, 120, 90},
+			{110, 110, 90},
+			{100, 130, 130},
+			{130, 100, 90},
+		}
+		for i, scores := range gameScores {
+			_, err = gameService.RegisterGame(&model.GameOutcome{
+				ID: strconv.Itoa(i + 1),
+				Players: []*model.PlayerResult{
+					{Name: "Player 1", Score: scores[0]},
+					{Name: "Player 2", Score: scores[1]},
+					{Name: "Player 3", Score: scores[2]},
+				},
+				FanFactionSetting: model.On,
+				CreationTime:      &currentTime,
+			})
+			require.NoError(t, err)
+		}
+
+		report, err := seasonReportService.GetSeasonReport()
+		require.NoError(t, err)
+		// Player 2 wins the first game and shares first place in the second and third game
+		assert.Equal(t, "Player 2", report.LongestWinStreak.PlayerName)
+		assert.Equal(t, 3, report.LongestWinStreak.Value)
+	})
+
+	t.Run("Test most improved climbs from the lowest Elo after a game", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		gameService := services.NewGame(playerRepo, gameRepo, seasonRepo)
+		leaderboardService := services.NewLeaderboard(seasonRepo, playerRepo, gameRepo)
+		seasonReportService := services.NewSeasonReport(seasonRepo, gameRepo, playerRepo, leaderboardService)
+
+		err := playerRepo.InsertPlayer("Player 1", "1")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 2", "2")
+		require.NoError(t, err)
+		err = playerRepo.InsertPlayer("Player 3", "3")
+		require.NoError(t, err)
+
+		currentTime := time.Now()
+		gameScores := [][]int{
+			{120, 110, 90},
+			{120, 110, 90},
+			{100, 110, 130},
+			{100, 110, 130},
+		}
+		for i, scores := range gameScores {
+			_, err = gameService.RegisterGame(&model.GameOutcome{
+				ID: strconv.Itoa(i + 1),
+				Players: []*model.PlayerResult{
+					{Name: "Player 1", Score: scores[0]},
+					{Name: "Player 2", Score: scores[1]},
+					{Name: "Player 3", Score: scores[2]},
+				},
+				FanFactionSetting: model.On,
+				CreationTime:      &currentTime,
+			})
+			require.NoError(t, err)
+		}
+
+		report, err := seasonReportService.GetSeasonReport()
+		require.NoError(t, err)
+		// Player 3 drops to 959 after the first two games and climbs back to 1007
+		assert.Equal(t, "Player 3", report.MostImproved.PlayerName)
+		assert.Equal(t, 48, report.MostImproved.Value)
+	})
+
+	t.Run("Test report without games", func(t *testing.T) {
+		t.Parallel()
+		dbx := newMigratedSQLiteDB(t)
+		queryTimeout := 2 * time.Second
+		gameRepo := repository.NewGame(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		playerRepo := repository.NewPlayer(dbx, &queryTimeout, testGuildID)
+		seasonRepo := repository.NewSeason(dbx, &queryTimeout, testGuildID, "First Fan Faction Season")
+		leaderboardService := services.NewLeaderboard(seasonRepo, playerRepo, gameRepo)
+		seasonReportService := services.NewSeasonReport(seasonRepo, gameRepo, playerRepo, leaderboardService)
+
+		report, err := seasonReportService.GetSeasonReport()
+		require.NoError(t, err)
+		assert.Empty(t, report.Standings.Entries)
+		assert.Nil(t, report.MostGamesPlayed)
+		assert.Nil(t, report.BiggestEloGain)
+		assert.Nil(t, report.HighestScore)
+		assert.Nil(t, report.LongestWinStreak)
+		assert.Nil(t, report.MostImproved)
+		assert.Contains(t, report.String(), "Most games played    -")
+	})
+}
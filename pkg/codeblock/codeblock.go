@@ -0,0 +1,67 @@
+package codeblock
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxMessageLength is the most characters Discord accepts in a message.
+const MaxMessageLength = 2000
+
+const fence = "```"
+
+// Split renders the table as code blocks that each fit into a message of maxLength characters. The first headerLines
+// lines of the table are repeated at the top of every block, so every message can be read on its own, and the title
+// line is numbered when the table doesn't fit into one message.
+func Split(table string, headerLines, maxLength int) []string {
+	lines := strings.Split(strings.TrimRight(table, "\n"), "\n")
+	headerLines = min(headerLines, len(lines))
+	header, rows := lines[:headerLines], lines[headerLines:]
+
+	// Leave room for the fences and the page number in the title
+	pageNumberLength := len(" (999/999)")
+	budget := maxLength - 2*len(fence+"\n") - pageNumberLength - length(header)
+
+	pages := Paginate(rows, budget)
+
+	blocks := make([]string, len(pages))
+	for i, rows := range pages {
+		pageHeader := append([]string{}, header...)
+		if len(pages) > 1 && len(pageHeader) > 0 {
+			pageHeader[0] += fmt.Sprintf(" (%d/%d)", i+1, len(pages))
+		}
+		blocks[i] = fmt.Sprintf("%s\n%s\n%s", fence, strings.Join(append(pageHeader, rows...), "\n"), fence)
+	}
+	return blocks
+}
+
+// Paginate groups the lines into pages of at most maxLength characters, counting a line break after every line. Lines
+// that don't fit on a page by themselves are cut off.
+func Paginate(lines []string, maxLength int) [][]string {
+	var pages [][]string
+	var page []string
+	pageLength := 0
+	for _, line := range lines {
+		if len(line)+1 > maxLength {
+			line = line[:max(maxLength-1, 0)]
+		}
+		if len(page) > 0 && pageLength+len(line)+1 > maxLength {
+			pages = append(pages, page)
+			page, pageLength = nil, 0
+		}
+		page = append(page, line)
+		pageLength += len(line) + 1
+	}
+	if len(page) > 0 || len(pages) == 0 {
+		pages = append(pages, page)
+	}
+	return pages
+}
+
+func length(lines []string) int {
+	total := 0
+	for _, line := range lines {
+		total += len(line) + 1
+	}
+	return total
+}
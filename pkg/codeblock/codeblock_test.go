@@ -0,0 +1,56 @@
+package codeblock_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"tmff-discord-app/pkg/codeblock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplit(t *testing.T) {
+	t.Parallel()
+	t.Run("Test table fits into one message", func(t *testing.T) {
+		t.Parallel()
+		blocks := codeblock.Split("Players\nName\n----\nPlayer 1\nPlayer 2\n", 3, codeblock.MaxMessageLength)
+		require.Len(t, blocks, 1)
+		assert.Equal(t, "```\nPlayers\nName\n----\nPlayer 1\nPlayer 2\n```", blocks[0])
+	})
+
+	t.Run("Test empty table", func(t *testing.T) {
+		t.Parallel()
+		blocks := codeblock.Split("Players\nName\n----\n", 3, codeblock.MaxMessageLength)
+		require.Len(t, blocks, 1)
+		assert.Equal(t, "```\nPlayers\nName\n----\n```", blocks[0])
+	})
+
+	t.Run("Test long table is split", func(t *testing.T) {
+		t.Parallel()
+		var sb strings.Builder
+		sb.WriteString("Leaderboard\nRank Player Name\n----------------\n")
+		for i := range 100 {
+			sb.WriteString(fmt.Sprintf("%-4d %-40s\n", i+1, fmt.Sprintf("Player %d", i+1)))
+		}
+
+		blocks := codeblock.Split(sb.String(), 3, codeblock.MaxMessageLength)
+		require.Len(t, blocks, 3)
+		rows := 0
+		for i, block := range blocks {
+			assert.LessOrEqual(t, len(block), codeblock.MaxMessageLength)
+			assert.True(t, strings.HasPrefix(block, fmt.Sprintf("```\nLeaderboard (%d/3)\nRank Player Name\n", i+1)))
+			assert.True(t, strings.HasSuffix(block, "\n```"))
+			rows += strings.Count(block, "\n") - 4
+		}
+		assert.Equal(t, 100, rows)
+		assert.Contains(t, blocks[2], "100  Player 100")
+	})
+}
+
+func TestPaginate(t *testing.T) {
+	t.Parallel()
+	pages := codeblock.Paginate([]string{"aaaa", "bbbb", "cccc", "dddddddddddd"}, 10)
+	assert.Equal(t, [][]string{{"aaaa", "bbbb"}, {"cccc"}, {"ddddddddd"}}, pages)
+	assert.Equal(t, [][]string{nil}, codeblock.Paginate(nil, 10))
+}
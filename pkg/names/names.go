@@ -0,0 +1,19 @@
+package names
+
+import (
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalize returns the key a player name is matched by. Names are compared in Unicode NFC and case folded, so a
+// name typed in Discord matches the same name scraped from BGA even if it is encoded or capitalized differently.
+func Normalize(name string) string {
+	return norm.NFC.String(cases.Fold().String(strings.TrimSpace(name)))
+}
+
+// Equal reports whether both names refer to the same player.
+func Equal(a, b string) bool {
+	return Normalize(a) == Normalize(b)
+}
@@ -0,0 +1,19 @@
+package names_test
+
+import (
+	"testing"
+	"tmff-discord-app/pkg/names"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+	// The same name composed (NFC) and decomposed (NFD)
+	assert.True(t, names.Equal("Stahlbr\u00f6tchen", "Stahlbro\u0308tchen"))
+	assert.True(t, names.Equal("Stahlbrötchen", "STAHLBRÖTCHEN"))
+	assert.True(t, names.Equal("Straße", "STRASSE"))
+	assert.True(t, names.Equal(" Zaarito", "zaarito "))
+	assert.False(t, names.Equal("Stahlbrötchen", "Stahlbrotchen"))
+	assert.False(t, names.Equal("player (1)", "player 1"))
+}
@@ -15,3 +15,9 @@ func Retry(timeout time.Duration, operation func() error) error {
 
 	return backoff.Retry(operation, exponentialBackoff)
 }
+
+// Permanent marks an error that retrying doesn't fix, Retry returns the error right away. The error keeps its message
+// and can still be unwrapped.
+func Permanent(err error) error {
+	return backoff.Permanent(err)
+}